@@ -0,0 +1,265 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/spf13/viper"
+)
+
+const (
+	// overflowLabelValue is the label value of series folded by a series limit
+	overflowLabelValue = "other"
+	// allMetricsSelector applies a series limit to every counter without one
+	allMetricsSelector = "*"
+)
+
+// cardinalityGatherer drops labels and caps the number of series per metric
+// before export.
+type cardinalityGatherer struct {
+	gatherer     prometheus.Gatherer
+	dropLabels   map[string][]string
+	seriesLimits map[string]int
+	defaultLimit int
+
+	// Registry of droppedSeries, gathered after the limits are applied so
+	// the counter includes the current gather
+	registry *prometheus.Registry
+
+	// Series kept by the previous gather per metric. Kept series stay kept
+	// while they exist, so counters do not move in and out of the other
+	// series and reset.
+	mu   sync.Mutex
+	kept map[string]map[string]struct{}
+}
+
+func newCardinalityGatherer(gatherer prometheus.Gatherer, deniedMetrics MetricsSet) (*cardinalityGatherer, error) {
+	g := &cardinalityGatherer{
+		gatherer:     gatherer,
+		dropLabels:   map[string][]string{},
+		seriesLimits: map[string]int{},
+		registry:     prometheus.NewRegistry(),
+		kept:         map[string]map[string]struct{}{},
+	}
+	if !deniedMetrics.Has(droppedSeriesMetricName) {
+		g.registry.MustRegister(droppedSeries)
+	}
+	allMetricsSet := buildAllMetricsSet()
+
+	for metric, labels := range parseMetricOptions(viper.GetString("metrics_drop_labels")) {
+		if !allMetricsSet.Has(MetricName(metric)) {
+			return nil, fmt.Errorf("metric %s doesn't exists", metric)
+		}
+		for _, label := range strings.Split(labels, "|") {
+			if label == "" {
+				return nil, fmt.Errorf("no labels to drop from metric %s", metric)
+			}
+			g.dropLabels[metric] = append(g.dropLabels[metric], label)
+		}
+	}
+
+	// The estimated label is always false without sampling_correction,
+	// dropping it never sums series
+	if !viper.GetBool("sampling_correction") {
+		for _, name := range estimatedMetrics() {
+			g.dropLabels[name.String()] = append(g.dropLabels[name.String()], estimatedLabel)
+		}
+	}
+
+	for metric, value := range parseMetricOptions(viper.GetString("metrics_series_limit")) {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("invalid series limit %q for metric %s", value, metric)
+		}
+		if metric == allMetricsSelector {
+			g.defaultLimit = limit
+			continue
+		}
+		if !allMetricsSet.Has(MetricName(metric)) {
+			return nil, fmt.Errorf("metric %s doesn't exists", metric)
+		}
+		g.seriesLimits[metric] = limit
+	}
+
+	return g, nil
+}
+
+// parseMetricOptions parses a comma delimited list of metric=value pairs.
+func parseMetricOptions(options string) map[string]string {
+	parsed := map[string]string{}
+	for _, option := range strings.Split(options, ",") {
+		if option == "" {
+			continue
+		}
+		metric, value, _ := strings.Cut(option, "=")
+		parsed[strings.TrimSpace(metric)] = strings.TrimSpace(value)
+	}
+	return parsed
+}
+
+func (g *cardinalityGatherer) Gather() ([]*dto.MetricFamily, error) {
+	mfs, err := g.gatherer.Gather()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, mf := range mfs {
+		if labels, exists := g.dropLabels[mf.GetName()]; exists {
+			mf.Metric = aggregateWithoutLabels(mf, labels)
+		}
+
+		limit, exists := g.seriesLimits[mf.GetName()]
+		if !exists && mf.GetType() == dto.MetricType_COUNTER {
+			// Summing info, ratio and other gauges is meaningless, the
+			// default limit only applies to counters
+			limit = g.defaultLimit
+		}
+		if limit == 0 {
+			continue
+		}
+
+		var dropped int
+		mf.Metric, dropped, g.kept[mf.GetName()] = foldOverflowSeries(mf, limit, g.kept[mf.GetName()])
+		if dropped > 0 {
+			droppedSeries.With(prometheus.Labels{"metric": mf.GetName()}).Add(float64(dropped))
+		}
+	}
+
+	own, ownErr := g.registry.Gather()
+	if ownErr != nil {
+		return mfs, ownErr
+	}
+	mfs = append(mfs, own...)
+	slices.SortFunc(mfs, func(a, b *dto.MetricFamily) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	return mfs, err
+}
+
+// metricValue returns the value of a counter, gauge or untyped series and
+// whether the series has such a value.
+func metricValue(m *dto.Metric) (float64, bool) {
+	switch {
+	case m.Counter != nil:
+		return m.Counter.GetValue(), true
+	case m.Gauge != nil:
+		return m.Gauge.GetValue(), true
+	case m.Untyped != nil:
+		return m.Untyped.GetValue(), true
+	}
+	return 0, false
+}
+
+// addMetricValue adds v to the value of a counter, gauge or untyped series.
+func addMetricValue(m *dto.Metric, v float64) {
+	current, _ := metricValue(m)
+	sum := current + v
+	switch {
+	case m.Counter != nil:
+		m.Counter.Value = &sum
+	case m.Gauge != nil:
+		m.Gauge.Value = &sum
+	case m.Untyped != nil:
+		m.Untyped.Value = &sum
+	}
+}
+
+// labelSignature identifies a series by its label values.
+func labelSignature(m *dto.Metric) string {
+	values := make([]string, 0, len(m.GetLabel()))
+	for _, lp := range m.GetLabel() {
+		values = append(values, lp.GetName()+"="+lp.GetValue())
+	}
+	return strings.Join(values, "\xff")
+}
+
+// aggregateWithoutLabels removes labels from the series of a metric family
+// and sums the values of series that become identical. Summaries and
+// histograms cannot be aggregated and are left unchanged.
+func aggregateWithoutLabels(mf *dto.MetricFamily, labels []string) []*dto.Metric {
+	if mf.GetType() == dto.MetricType_SUMMARY || mf.GetType() == dto.MetricType_HISTOGRAM {
+		log.Warnf("cannot drop labels from metric %s of type %s", mf.GetName(), mf.GetType())
+		return mf.Metric
+	}
+
+	aggregated := make([]*dto.Metric, 0, len(mf.Metric))
+	seen := make(map[string]*dto.Metric, len(mf.Metric))
+	for _, m := range mf.Metric {
+		m.Label = slices.DeleteFunc(m.Label, func(lp *dto.LabelPair) bool {
+			return slices.Contains(labels, lp.GetName())
+		})
+		signature := labelSignature(m)
+		if existing, exists := seen[signature]; exists {
+			v, _ := metricValue(m)
+			addMetricValue(existing, v)
+			continue
+		}
+		seen[signature] = m
+		aggregated = append(aggregated, m)
+	}
+	return aggregated
+}
+
+// foldOverflowSeries keeps limit-1 series and sums the rest into a single
+// series with every label set to "other". Series kept by the previous call
+// are kept first, free slots go to the series with the highest values. It
+// returns the remaining series, the number of series folded and the label
+// signatures of the kept series.
+func foldOverflowSeries(mf *dto.MetricFamily, limit int, previous map[string]struct{}) ([]*dto.Metric, int, map[string]struct{}) {
+	metrics := mf.Metric
+	signatures := make(map[*dto.Metric]string, len(metrics))
+	for _, m := range metrics {
+		signatures[m] = labelSignature(m)
+	}
+	if len(metrics) <= limit {
+		kept := make(map[string]struct{}, len(metrics))
+		for _, signature := range signatures {
+			kept[signature] = struct{}{}
+		}
+		return metrics, 0, kept
+	}
+
+	slices.SortStableFunc(metrics, func(a, b *dto.Metric) int {
+		_, aKept := previous[signatures[a]]
+		_, bKept := previous[signatures[b]]
+		if aKept != bKept {
+			if aKept {
+				return -1
+			}
+			return 1
+		}
+		va, _ := metricValue(a)
+		vb, _ := metricValue(b)
+		if c := cmp.Compare(vb, va); c != 0 {
+			return c
+		}
+		return strings.Compare(signatures[a], signatures[b])
+	})
+
+	kept, overflow := metrics[:limit-1], metrics[limit-1:]
+	keptSignatures := make(map[string]struct{}, limit)
+	for _, m := range kept {
+		keptSignatures[signatures[m]] = struct{}{}
+	}
+	other := overflow[0]
+	if _, ok := metricValue(other); !ok {
+		// Summaries and histograms cannot be summed, drop the overflow instead
+		keptSignatures[signatures[other]] = struct{}{}
+		return metrics[:limit], len(metrics) - limit, keptSignatures
+	}
+	for _, lp := range other.Label {
+		value := overflowLabelValue
+		lp.Value = &value
+	}
+	for _, m := range overflow[1:] {
+		v, _ := metricValue(m)
+		addMetricValue(other, v)
+	}
+	return append(kept, other), len(overflow), keptSignatures
+}
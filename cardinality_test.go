@@ -0,0 +1,212 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func counterSeries(labels map[string]string, value float64) *dto.Metric {
+	m := &dto.Metric{Counter: &dto.Counter{Value: &value}}
+	for name, value := range labels {
+		m.Label = append(m.Label, &dto.LabelPair{Name: &name, Value: &value})
+	}
+	return m
+}
+
+// seriesValues returns the values of the series by the value of a label.
+func seriesValues(metrics []*dto.Metric, label string) map[string]float64 {
+	values := make(map[string]float64, len(metrics))
+	for _, m := range metrics {
+		for _, lp := range m.GetLabel() {
+			if lp.GetName() == label {
+				v, _ := metricValue(m)
+				values[lp.GetValue()] += v
+			}
+		}
+	}
+	return values
+}
+
+func TestAggregateWithoutLabels(t *testing.T) {
+	tests := []struct {
+		name   string
+		series []*dto.Metric
+		labels []string
+		want   map[string]float64
+	}{
+		{
+			name: "series differing only in a dropped label are summed",
+			series: []*dto.Metric{
+				counterSeries(map[string]string{"zone": "a", "status": "200"}, 1),
+				counterSeries(map[string]string{"zone": "a", "status": "500"}, 2),
+				counterSeries(map[string]string{"zone": "b", "status": "200"}, 4),
+			},
+			labels: []string{"status"},
+			want:   map[string]float64{"a": 3, "b": 4},
+		},
+		{
+			name: "labels not present are ignored",
+			series: []*dto.Metric{
+				counterSeries(map[string]string{"zone": "a"}, 1),
+				counterSeries(map[string]string{"zone": "b"}, 2),
+			},
+			labels: []string{"status"},
+			want:   map[string]float64{"a": 1, "b": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, metricType := "test_count", dto.MetricType_COUNTER
+			mf := &dto.MetricFamily{Name: &name, Type: &metricType, Metric: tt.series}
+
+			metrics := aggregateWithoutLabels(mf, tt.labels)
+
+			if len(metrics) != len(tt.want) {
+				t.Fatalf("got %d series, want %d", len(metrics), len(tt.want))
+			}
+			for _, m := range metrics {
+				for _, lp := range m.GetLabel() {
+					for _, dropped := range tt.labels {
+						if lp.GetName() == dropped {
+							t.Errorf("label %s not dropped", dropped)
+						}
+					}
+				}
+			}
+			got := seriesValues(metrics, "zone")
+			for zone, want := range tt.want {
+				if got[zone] != want {
+					t.Errorf("zone %s = %v, want %v", zone, got[zone], want)
+				}
+			}
+		})
+	}
+}
+
+// counterFamily builds a counter family with one series per value, labelled
+// with the given label name.
+func counterFamily(label string, values map[string]float64) *dto.MetricFamily {
+	name, metricType := "test_count", dto.MetricType_COUNTER
+	mf := &dto.MetricFamily{Name: &name, Type: &metricType}
+	for labelValue, value := range values {
+		mf.Metric = append(mf.Metric, counterSeries(map[string]string{label: labelValue}, value))
+	}
+	return mf
+}
+
+func TestFoldOverflowSeries(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      map[string]float64
+		limit       int
+		previous    []string
+		wantValues  map[string]float64
+		wantDropped int
+		wantKept    []string
+	}{
+		{
+			name:        "under the limit",
+			values:      map[string]float64{"a": 5, "b": 1},
+			limit:       3,
+			wantValues:  map[string]float64{"a": 5, "b": 1},
+			wantDropped: 0,
+			wantKept:    []string{"a", "b"},
+		},
+		{
+			name:        "highest values are kept",
+			values:      map[string]float64{"a": 5, "b": 1, "c": 3, "d": 2},
+			limit:       3,
+			wantValues:  map[string]float64{"a": 5, "c": 3, overflowLabelValue: 3},
+			wantDropped: 2,
+			wantKept:    []string{"a", "c"},
+		},
+		{
+			name:        "previously kept series stay kept",
+			values:      map[string]float64{"a": 5, "b": 1, "c": 3, "d": 2},
+			limit:       3,
+			previous:    []string{"b"},
+			wantValues:  map[string]float64{"a": 5, "b": 1, overflowLabelValue: 5},
+			wantDropped: 2,
+			wantKept:    []string{"a", "b"},
+		},
+		{
+			name:        "previously kept series that disappeared free their slot",
+			values:      map[string]float64{"a": 5, "c": 3, "d": 2},
+			limit:       2,
+			previous:    []string{"b", "d"},
+			wantValues:  map[string]float64{"d": 2, overflowLabelValue: 8},
+			wantDropped: 2,
+			wantKept:    []string{"d"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := make(map[string]struct{}, len(tt.previous))
+			for _, value := range tt.previous {
+				previous[labelSignature(counterSeries(map[string]string{"zone": value}, 0))] = struct{}{}
+			}
+
+			metrics, dropped, kept := foldOverflowSeries(counterFamily("zone", tt.values), tt.limit, previous)
+
+			if dropped != tt.wantDropped {
+				t.Errorf("dropped = %d, want %d", dropped, tt.wantDropped)
+			}
+			got := seriesValues(metrics, "zone")
+			if len(got) != len(tt.wantValues) {
+				t.Errorf("series = %v, want %v", got, tt.wantValues)
+			}
+			for value, want := range tt.wantValues {
+				if got[value] != want {
+					t.Errorf("series %s = %v, want %v", value, got[value], want)
+				}
+			}
+			if len(kept) != len(tt.wantKept) {
+				t.Errorf("kept %d series, want %d", len(kept), len(tt.wantKept))
+			}
+			for _, value := range tt.wantKept {
+				if _, exists := kept[labelSignature(counterSeries(map[string]string{"zone": value}, 0))]; !exists {
+					t.Errorf("series %s not kept", value)
+				}
+			}
+		})
+	}
+}
+
+func TestCardinalityGathererCountsDroppedSeries(t *testing.T) {
+	const metric = "test_dropped_series_count"
+	family := func() *dto.MetricFamily {
+		name := metric
+		mf := counterFamily("zone", map[string]float64{"a": 3, "b": 2, "c": 1})
+		mf.Name = &name
+		return mf
+	}
+	g := &cardinalityGatherer{
+		gatherer: prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+			return []*dto.MetricFamily{family()}, nil
+		}),
+		seriesLimits: map[string]int{metric: 2},
+		registry:     prometheus.NewRegistry(),
+		kept:         map[string]map[string]struct{}{},
+	}
+	g.registry.MustRegister(droppedSeries)
+
+	for _, want := range []float64{2, 4} {
+		mfs, err := g.Gather()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got float64
+		for _, mf := range mfs {
+			if mf.GetName() == droppedSeriesMetricName.String() {
+				got = seriesValues(mf.Metric, "metric")[metric]
+			}
+		}
+		if got != want {
+			t.Errorf("dropped series = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// API token scopes required by the collectors.
+const (
+	scopeZoneAnalytics     = "Zone/Analytics:Read"
+	scopeAccountAnalytics  = "Account/Account Analytics:Read"
+	scopeAccountSettings   = "Account/Account Settings:Read"
+	scopeFirewallServices  = "Zone/Firewall Services:Read"
+	scopeAccountRulesets   = "Account/Account Rulesets:Read"
+	scopeZoneWAF           = "Zone/Zone WAF:Read"
+	scopeLoadBalancing     = "Account/Load Balancing: Monitors and Pools:Read"
+	scopeAPIGateway        = "Zone/API Gateway:Read"
+	scopePageShield        = "Zone/Page Shield:Read"
+	scopeWaitingRooms      = "Zone/Waiting Rooms:Read"
+	scopeEmailRoutingRules = "Zone/Email Routing Rules:Read"
+	scopeEmailRoutingAddrs = "Account/Email Routing Addresses:Read"
+	scopeImages            = "Account/Cloudflare Images:Read"
+	scopeStream            = "Account/Stream:Read"
+	scopeTurnstile         = "Account/Turnstile:Read"
+	scopeAccountLogs       = "Account/Logs:Read"
+	scopeZoneLogs          = "Zone/Logs:Read"
+	scopeWorkersScripts    = "Account/Workers Scripts:Read"
+	scopeWorkersRoutes     = "Zone/Workers Routes:Read"
+	scopeCloudflareTunnel  = "Account/Cloudflare Tunnel:Read"
+)
+
+const (
+	metricCatalogPath = "/metrics-catalog"
+	metricTypeCounter = "counter"
+	metricTypeGauge   = "gauge"
+	metricTypeSummary = "summary"
+	// Selectors with regexp characters are regular expressions, selectors
+	// with only glob characters are globs
+	metricSelectorGlobChars   = "*?"
+	metricSelectorRegexpChars = `.+()[]{}|^$\`
+)
+
+type metricCatalogGroup struct {
+	collector string
+	scopes    []string
+	metrics   []MetricName
+}
+
+// metricCatalogGroups lists the collector and the API token scopes of every
+// metric.
+var metricCatalogGroups = []metricCatalogGroup{
+	{"fetchZoneAnalytics", []string{scopeZoneAnalytics, scopeFirewallServices, scopeAccountRulesets}, []MetricName{
+		zoneRequestTotalMetricName, zoneRequestCachedMetricName, zoneRequestSSLEncryptedMetricName,
+		zoneRequestContentTypeMetricName, zoneRequestCountryMetricName, zoneRequestHTTPStatusMetricName,
+		zoneRequestBrowserMapMetricName, zoneRequestOriginStatusCountryHostMetricName, zoneRequestStatusCountryHostMetricName,
+		zoneBandwidthTotalMetricName, zoneBandwidthCachedMetricName, zoneBandwidthSSLEncryptedMetricName,
+		zoneBandwidthContentTypeMetricName, zoneBandwidthCountryMetricName, zoneThreatsTotalMetricName,
+		zoneThreatsCountryMetricName, zoneThreatsTypeMetricName, zonePageviewsTotalMetricName,
+		zoneUniquesTotalMetricName, zoneFirewallEventsCountMetricName, zoneHealthCheckEventsOriginCountMetricName,
+		zoneSampleIntervalMetricName,
+	}},
+	{"fetchZoneColocationAnalytics", []string{scopeZoneAnalytics}, []MetricName{
+		zoneColocationVisitsMetricName, zoneColocationEdgeResponseBytesMetricName, zoneColocationRequestsTotalMetricName,
+	}},
+	{"fetchBotManagementAnalytics", []string{scopeZoneAnalytics}, []MetricName{
+		zoneBotRequestsCountMetricName,
+	}},
+	{"fetchSecurityAnalytics", []string{scopeZoneAnalytics, scopeZoneWAF}, []MetricName{
+		zoneSecurityRuleHitsCountMetricName, zoneWAFOWASPEventsCountMetricName, zoneWAFAttackScoreRequestsCountMetricName,
+		zoneRulesetInfoMetricName, zoneWAFOWASPScoreThresholdMetricName,
+	}},
+	{"fetchAPIShieldAnalytics", []string{scopeZoneAnalytics, scopeAPIGateway}, []MetricName{
+		zoneAPIShieldDiscoveredEndpointsMetricName, zoneAPIShieldSchemaViolationsCountMetricName,
+		zoneAPIShieldSequenceMitigationCountMetricName,
+	}},
+	{"fetchPageShieldAnalytics", []string{scopePageShield}, []MetricName{
+		zonePageShieldScriptsMetricName, zonePageShieldConnectionsMetricName,
+	}},
+	{"fetchSpectrumAnalytics", []string{scopeZoneAnalytics}, []MetricName{
+		zoneSpectrumBytesMetricName, zoneSpectrumPacketsMetricName, zoneSpectrumActiveConnectionsMetricName,
+	}},
+	{"fetchDDoSAnalytics", []string{scopeZoneAnalytics}, []MetricName{
+		zoneDDoSMitigatedRequestsCountMetricName, zoneDDoSAttackInProgressMetricName, zoneDDoSAttacksMetricName,
+	}},
+	{"fetchWaitingRoomAnalytics", []string{scopeZoneAnalytics, scopeWaitingRooms}, []MetricName{
+		zoneWaitingRoomTotalActiveUsersLimitMetricName, zoneWaitingRoomNewUsersPerMinuteLimitMetricName,
+		zoneWaitingRoomStatusMetricName, zoneWaitingRoomQueuedUsersMetricName, zoneWaitingRoomActiveUsersMetricName,
+		zoneWaitingRoomEstimatedWaitTimeMetricName, zoneWaitingRoomAdmittedUsersPerMinuteMetricName,
+	}},
+	{"fetchEmailRoutingAnalytics", []string{scopeZoneAnalytics, scopeEmailRoutingRules}, []MetricName{
+		zoneEmailRoutingMessagesMetricName, zoneEmailRoutingAuthResultsMetricName, zoneEmailRoutingRulesMetricName,
+	}},
+	{"fetchEmailRoutingAddressesForAccount", []string{scopeEmailRoutingAddrs}, []MetricName{
+		emailRoutingDestinationAddressesMetricName,
+	}},
+	{"fetchArgoAnalytics", []string{scopeZoneAnalytics}, []MetricName{
+		zoneArgoRequestsMetricName, zoneArgoOriginResponseDurationMetricName, zoneArgoSmartRoutedRatioMetricName,
+		zoneTieredCacheRequestsMetricName, zoneTieredCacheUpperTierHitRatioMetricName,
+	}},
+	{"fetchTopNAnalytics", []string{scopeZoneAnalytics}, []MetricName{
+		zoneTopPathRequestsMetricName, zoneTopPathBytesMetricName, zoneTopHostRequestsMetricName,
+		zoneTopHostBytesMetricName, zoneTopUserAgentRequestsMetricName, zoneTopUserAgentBytesMetricName,
+		zoneTopASNRequestsMetricName, zoneTopASNBytesMetricName, zoneTopClientIPRequestsMetricName,
+		zoneTopClientIPBytesMetricName, zoneTopDeviceTypeRequestsMetricName, zoneTopDeviceTypeBytesMetricName,
+		zoneTopASNThreatsMetricName,
+	}},
+	{"fetchLoadBalancerAnalytics", []string{scopeZoneAnalytics}, []MetricName{
+		poolHealthStatusMetricName, poolRequestsTotalMetricName,
+	}},
+	{"fetchLogpushAnalyticsForZone", []string{scopeZoneAnalytics, scopeZoneLogs}, []MetricName{
+		logpushFailedJobsZoneMetricName,
+	}},
+	{"fetchWorkerRoutesAnalytics", []string{scopeWorkersRoutes}, []MetricName{
+		workerRoutesMetricName,
+	}},
+	{"fetchWorkerAnalytics", []string{scopeAccountAnalytics, scopeAccountSettings}, []MetricName{
+		workerRequestsMetricName, workerErrorsMetricName, workerCPUTimeMetricName, workerDurationMetricName,
+		workerWallTimeMetricName, workerSubrequestsMetricName,
+	}},
+	{"fetchWorkerInventoryForAccount", []string{scopeAccountAnalytics, scopeWorkersScripts}, []MetricName{
+		workerScriptInfoMetricName, workerLastDeploymentMetricName, workerCustomDomainsMetricName,
+		workerCronTriggerInfoMetricName, workerCronExecutionsMetricName,
+	}},
+	{"fetchAIAnalytics", []string{scopeAccountAnalytics}, []MetricName{
+		aiGatewayRequestsMetricName, aiGatewayCachedRequestsMetricName, aiGatewayErrorsMetricName,
+		aiGatewayTokensMetricName, aiGatewayCostMetricName, workersAIInferencesMetricName, workersAINeuronsMetricName,
+	}},
+	{"fetchLogpushAnalyticsForAccount", []string{scopeAccountAnalytics, scopeAccountLogs, scopeZoneLogs}, []MetricName{
+		logpushFailedJobsAccountMetricName, logpushJobInfoMetricName, logpushJobLastCompleteMetricName,
+		logpushJobLastErrorMetricName, logpushJobDeliveryLagMetricName, logpushUploadsMetricName,
+		logpushBytesMetricName, logpushRecordsMetricName,
+	}},
+	{"fetchR2StorageForAccount", []string{scopeAccountAnalytics}, []MetricName{
+		r2StorageTotalMetricName, r2StorageMetricName, r2ObjectsMetricName, r2MetadataStorageMetricName,
+		r2OperationMetricName, r2EgressMetricName,
+	}},
+	{"fetchImagesUsageForAccount", []string{scopeAccountAnalytics, scopeImages}, []MetricName{
+		imagesStoredMetricName, imagesStoredLimitMetricName, imagesVariantRequestsMetricName,
+		imagesTransformationsMetricName,
+	}},
+	{"fetchStreamUsageForAccount", []string{scopeAccountAnalytics, scopeStream}, []MetricName{
+		streamStorageMinutesMetricName, streamStorageMinutesLimitMetricName, streamVideosMetricName,
+		streamMinutesDeliveredMetricName, streamVideoViewsMetricName, streamVideoMinutesViewedMetricName,
+	}},
+	{"fetchTurnstileAnalyticsForAccount", []string{scopeAccountAnalytics, scopeTurnstile}, []MetricName{
+		turnstileWidgetInfoMetricName, turnstileChallengesMetricName, turnstileChallengeErrorsMetricName,
+	}},
+	{"fetchLoadblancerPoolsHealth", []string{scopeLoadBalancing}, []MetricName{
+		poolOriginHealthStatusMetricName,
+	}},
+	{"fetchZeroTrustAnalyticsForAccount", []string{scopeAccountAnalytics, scopeCloudflareTunnel}, []MetricName{
+		tunnelInfoMetricName, tunnelHealthStatusMetricName, tunnelConnectorInfoMetricName,
+		tunnelConnectorActiveConnectionsMetricName, tunnelConnectorVersionSkewMetricName, tunnelConnectionInfoMetricName,
+		tunnelConnectionAgeMetricName, tunnelConnectionPendingReconnectMetricName, tunnelRequestsMetricName,
+		tunnelBytesMetricName,
+	}},
+	{"fetchMagicTransitAnalyticsForAccount", []string{scopeAccountAnalytics}, []MetricName{
+		magicTransitBitsMetricName, magicTransitPacketsMetricName,
+	}},
+	{"fetchDDoSAnalyticsForAccount", []string{scopeAccountAnalytics}, []MetricName{
+		ddosAttacksMetricName, ddosPacketsMetricName, ddosBitsMetricName, ddosAttackInProgressMetricName,
+	}},
+	{"fetchRUMAnalyticsForAccount", []string{scopeAccountAnalytics}, []MetricName{
+		rumPageLoadsMetricName, rumLargestContentfulPaintMetricName, rumInteractionToNextPaintMetricName,
+		rumCumulativeLayoutShiftMetricName, rumTimeToFirstByteMetricName, rumFirstContentfulPaintMetricName,
+	}},
+	{"cost estimation", nil, []MetricName{
+		estimatedCostMetricName,
+	}},
+	{"exporter", nil, []MetricName{
+		droppedSeriesMetricName,
+	}},
+}
+
+type metricCatalogEntry struct {
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	Help      string   `json:"help"`
+	Labels    []string `json:"labels"`
+	Collector string   `json:"collector"`
+	Scopes    []string `json:"scopes"`
+	Enabled   bool     `json:"enabled"`
+}
+
+type metricDefinition struct {
+	metricType string
+	help       string
+	labels     []string
+}
+
+// metricDefinitions holds the type, help and variable labels of every metric
+// collector, recorded where the metric is defined. The client library only
+// exposes them as a descriptor string.
+var metricDefinitions = map[prometheus.Collector]metricDefinition{}
+
+func newCounterVec(opts prometheus.CounterOpts, labelNames []string) *prometheus.CounterVec {
+	c := prometheus.NewCounterVec(opts, labelNames)
+	metricDefinitions[c] = metricDefinition{metricType: metricTypeCounter, help: opts.Help, labels: labelNames}
+	return c
+}
+
+func newGaugeVec(opts prometheus.GaugeOpts, labelNames []string) *prometheus.GaugeVec {
+	g := prometheus.NewGaugeVec(opts, labelNames)
+	metricDefinitions[g] = metricDefinition{metricType: metricTypeGauge, help: opts.Help, labels: labelNames}
+	return g
+}
+
+// costCollectors record the usage of cloudflare_estimated_cost_usd.
+var costCollectors = []string{
+	"fetchWorkerAnalytics", "fetchR2StorageForAccount", "fetchImagesUsageForAccount",
+	"fetchStreamUsageForAccount", "fetchArgoAnalytics", "fetchLoadBalancerAnalytics",
+}
+
+// enabledCollectors returns the collectors with at least one enabled metric.
+// Collectors recording cost usage also run when the cost metric is enabled.
+func enabledCollectors(deniedMetrics MetricsSet) map[string]bool {
+	enabled := map[string]bool{}
+	for _, group := range metricCatalogGroups {
+		for _, name := range group.metrics {
+			if !deniedMetrics.Has(name) {
+				enabled[group.collector] = true
+			}
+		}
+	}
+	if !deniedMetrics.Has(estimatedCostMetricName) {
+		for _, collector := range costCollectors {
+			enabled[collector] = true
+		}
+	}
+	return enabled
+}
+
+// buildMetricCatalog describes every metric the exporter can export.
+func buildMetricCatalog(deniedMetrics MetricsSet) []metricCatalogEntry {
+	collectors := metricCollectors()
+	catalog := make([]metricCatalogEntry, 0, len(collectors))
+	for _, group := range metricCatalogGroups {
+		for _, name := range group.metrics {
+			definition := metricDefinitions[collectors[name]]
+			catalog = append(catalog, metricCatalogEntry{
+				Name:      name.String(),
+				Type:      definition.metricType,
+				Help:      definition.help,
+				Labels:    exportedLabels(definition.labels),
+				Collector: group.collector,
+				Scopes:    group.scopes,
+				Enabled:   !deniedMetrics.Has(name),
+			})
+		}
+	}
+	slices.SortFunc(catalog, func(a, b metricCatalogEntry) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return catalog
+}
+
+// metricCatalogHandler serves the metric catalog as JSON.
+func metricCatalogHandler(deniedMetrics MetricsSet) http.HandlerFunc {
+	catalog := buildMetricCatalog(deniedMetrics)
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(catalog); err != nil {
+			log.Errorf("failed to encode metric catalog, err:%v", err)
+		}
+	}
+}
+
+// selectMetrics returns the metrics matching a metric name, a glob pattern
+// such as cloudflare_zone_colocation_* or an anchored regular expression
+// such as cloudflare_zone_colocation_.*. Selectors matching no metric are
+// an error.
+func selectMetrics(selector string, allMetricsSet MetricsSet) ([]MetricName, error) {
+	if allMetricsSet.Has(MetricName(selector)) {
+		return []MetricName{MetricName(selector)}, nil
+	}
+
+	pattern := selector
+	if !strings.ContainsAny(selector, metricSelectorRegexpChars) {
+		if !strings.ContainsAny(selector, metricSelectorGlobChars) {
+			return nil, fmt.Errorf("metric %s doesn't exists", selector)
+		}
+		pattern = strings.NewReplacer("*", ".*", "?", ".").Replace(selector)
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid metric selector %s: %w", selector, err)
+	}
+
+	var matched []MetricName
+	for name := range allMetricsSet {
+		if re.MatchString(name.String()) {
+			matched = append(matched, name)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("metric selector %s doesn't match any metric", selector)
+	}
+	return matched, nil
+}
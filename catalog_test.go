@@ -0,0 +1,99 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSelectMetrics(t *testing.T) {
+	all := MetricsSet{}
+	for _, name := range []MetricName{
+		zoneColocationVisitsMetricName, zoneColocationEdgeResponseBytesMetricName,
+		zoneColocationRequestsTotalMetricName, zoneRequestTotalMetricName,
+	} {
+		all.Add(name)
+	}
+
+	tests := []struct {
+		selector string
+		want     []MetricName
+		wantErr  bool
+	}{
+		{
+			selector: "cloudflare_zone_requests_total",
+			want:     []MetricName{zoneRequestTotalMetricName},
+		},
+		{
+			selector: "cloudflare_zone_colocation_*",
+			want: []MetricName{
+				zoneColocationEdgeResponseBytesMetricName, zoneColocationRequestsTotalMetricName,
+				zoneColocationVisitsMetricName,
+			},
+		},
+		{
+			selector: "cloudflare_zone_colocation_(visits|requests_total)",
+			want:     []MetricName{zoneColocationRequestsTotalMetricName, zoneColocationVisitsMetricName},
+		},
+		{
+			// Regular expressions match the whole name
+			selector: "colocation.*",
+			wantErr:  true,
+		},
+		{selector: "cloudflare_zone_unknown", wantErr: true},
+		{selector: "cloudflare_zone_unknown_*", wantErr: true},
+		{selector: "cloudflare_zone_(", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got, err := selectMetrics(tt.selector, all)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectMetrics(%q) error = %v, wantErr %t", tt.selector, err, tt.wantErr)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("selectMetrics(%q) = %v, want %v", tt.selector, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetricCatalogCoversAllMetrics(t *testing.T) {
+	collectors := metricCollectors()
+	catalogued := MetricsSet{}
+	for _, group := range metricCatalogGroups {
+		for _, name := range group.metrics {
+			catalogued.Add(name)
+			if _, exists := metricDefinitions[collectors[name]]; !exists {
+				t.Errorf("metric %s has no definition", name)
+			}
+		}
+	}
+	for name := range buildAllMetricsSet() {
+		if !catalogued.Has(name) {
+			t.Errorf("metric %s is missing in the catalog", name)
+		}
+	}
+}
+
+func TestEnabledCollectors(t *testing.T) {
+	denied := MetricsSet{}
+	for name := range buildAllMetricsSet() {
+		if name != zoneTopPathRequestsMetricName && name != estimatedCostMetricName {
+			denied.Add(name)
+		}
+	}
+
+	enabled := enabledCollectors(denied)
+
+	for _, collector := range append([]string{"fetchTopNAnalytics"}, costCollectors...) {
+		if !enabled[collector] {
+			t.Errorf("collector %s not enabled", collector)
+		}
+	}
+	for _, collector := range []string{"fetchZoneAnalytics", "fetchSecurityAnalytics", "fetchZeroTrustAnalyticsForAccount"} {
+		if enabled[collector] {
+			t.Errorf("collector %s enabled without enabled metrics", collector)
+		}
+	}
+}
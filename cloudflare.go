@@ -2,13 +2,26 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"strings"
+	"sync"
 
 	cf "github.com/cloudflare/cloudflare-go/v4"
 	cfaccounts "github.com/cloudflare/cloudflare-go/v4/accounts"
+	cfapi_gateway "github.com/cloudflare/cloudflare-go/v4/api_gateway"
+	cfemail_routing "github.com/cloudflare/cloudflare-go/v4/email_routing"
+	cfimages "github.com/cloudflare/cloudflare-go/v4/images"
 	cfload_balancers "github.com/cloudflare/cloudflare-go/v4/load_balancers"
+	cflogpush "github.com/cloudflare/cloudflare-go/v4/logpush"
 	cfpagination "github.com/cloudflare/cloudflare-go/v4/packages/pagination"
+	cfpage_shield "github.com/cloudflare/cloudflare-go/v4/page_shield"
 	cfrulesets "github.com/cloudflare/cloudflare-go/v4/rulesets"
+	cfspectrum "github.com/cloudflare/cloudflare-go/v4/spectrum"
+	cfstream "github.com/cloudflare/cloudflare-go/v4/stream"
+	cfturnstile "github.com/cloudflare/cloudflare-go/v4/turnstile"
+	cfwaiting_rooms "github.com/cloudflare/cloudflare-go/v4/waiting_rooms"
+	cfworkers "github.com/cloudflare/cloudflare-go/v4/workers"
 	cfzero_trust "github.com/cloudflare/cloudflare-go/v4/zero_trust"
 	cfzones "github.com/cloudflare/cloudflare-go/v4/zones"
 
@@ -16,8 +29,9 @@ import (
 )
 
 const (
-	freePlanID      = "0feeeeeeeeeeeeeeeeeeeeeeeeeeeeee"
-	apiPerPageLimit = 999
+	freePlanID            = "0feeeeeeeeeeeeeeeeeeeeeeeeeeeeee"
+	owaspManagedRulesetID = "4814384a9e5d4991b9815dcfc25d2f1f"
+	apiPerPageLimit       = 999
 )
 
 type cloudflareResponse struct {
@@ -38,6 +52,72 @@ type cloudflareResponseColo struct {
 	} `json:"viewer"`
 }
 
+type cloudflareResponseBots struct {
+	Viewer struct {
+		Zones []zoneRespBots `json:"zones"`
+	} `json:"viewer"`
+}
+
+type cloudflareResponseSecurity struct {
+	Viewer struct {
+		Zones []zoneRespSecurity `json:"zones"`
+	} `json:"viewer"`
+}
+
+type cloudflareResponseAPIShield struct {
+	Viewer struct {
+		Zones []zoneRespAPIShield `json:"zones"`
+	} `json:"viewer"`
+}
+
+type cloudflareResponseSpectrum struct {
+	Viewer struct {
+		Zones []zoneRespSpectrum `json:"zones"`
+	} `json:"viewer"`
+}
+
+type cloudflareResponseMagicTransit struct {
+	Viewer struct {
+		Accounts []magicTransitAccountResp `json:"accounts"`
+	} `json:"viewer"`
+}
+
+type cloudflareResponseDDoS struct {
+	Viewer struct {
+		Zones []zoneRespDDoS `json:"zones"`
+	} `json:"viewer"`
+}
+
+type cloudflareResponseDDoSAccount struct {
+	Viewer struct {
+		Accounts []ddosAccountResp `json:"accounts"`
+	} `json:"viewer"`
+}
+
+type cloudflareResponseRUM struct {
+	Viewer struct {
+		Accounts []rumAccountResp `json:"accounts"`
+	} `json:"viewer"`
+}
+
+type cloudflareResponseWaitingRoom struct {
+	Viewer struct {
+		Zones []zoneRespWaitingRoom `json:"zones"`
+	} `json:"viewer"`
+}
+
+type cloudflareResponseEmailRouting struct {
+	Viewer struct {
+		Zones []zoneRespEmailRouting `json:"zones"`
+	} `json:"viewer"`
+}
+
+type cloudflareResponseArgo struct {
+	Viewer struct {
+		Zones []zoneRespArgo `json:"zones"`
+	} `json:"viewer"`
+}
+
 type cloudflareResponseLb struct {
 	Viewer struct {
 		Zones []lbResp `json:"zones"`
@@ -53,7 +133,8 @@ type cloudflareResponseLogpushAccount struct {
 type r2AccountResp struct {
 	R2StorageGroups []struct {
 		Dimensions struct {
-			BucketName string `json:"bucketName"`
+			BucketName   string `json:"bucketName"`
+			StorageClass string `json:"storageClass"`
 		} `json:"dimensions"`
 		Max struct {
 			MetadataSize uint64 `json:"metadataSize"`
@@ -64,21 +145,186 @@ type r2AccountResp struct {
 
 	R2StorageOperations []struct {
 		Dimensions struct {
-			Action     string `json:"actionType"`
-			BucketName string `json:"bucketName"`
+			Action             string `json:"actionType"`
+			BucketName         string `json:"bucketName"`
+			StorageClass       string `json:"storageClass"`
+			ResponseStatusCode int    `json:"responseStatusCode"`
 		} `json:"dimensions"`
 		Sum struct {
-			Requests uint64 `json:"requests"`
+			Requests      uint64 `json:"requests"`
+			ResponseBytes uint64 `json:"responseBytes"`
 		} `json:"sum"`
 	} `json:"r2OperationsAdaptiveGroups"`
 }
 
+type magicTransitAccountResp struct {
+	MagicTransitNetworkAnalyticsAdaptiveGroups []struct {
+		Dimensions struct {
+			IPDestinationSubnet string `json:"ipDestinationSubnet"`
+			IPProtocolName      string `json:"ipProtocolName"`
+			ColoCode            string `json:"coloCode"`
+			Outcome             string `json:"outcome"`
+		} `json:"dimensions"`
+		Sum struct {
+			Bits    uint64 `json:"bits"`
+			Packets uint64 `json:"packets"`
+		} `json:"sum"`
+		Avg struct {
+			SampleInterval float64 `json:"sampleInterval"`
+		} `json:"avg"`
+	} `json:"magicTransitNetworkAnalyticsAdaptiveGroups"`
+}
+
+type ddosAccountResp struct {
+	DosdNetworkAnalyticsAdaptiveGroups []struct {
+		Dimensions struct {
+			AttackID     string `json:"attackId"`
+			AttackVector string `json:"attackVector"`
+			RuleID       string `json:"ruleId"`
+			Outcome      string `json:"outcome"`
+		} `json:"dimensions"`
+		Sum struct {
+			Bits    uint64 `json:"bits"`
+			Packets uint64 `json:"packets"`
+		} `json:"sum"`
+		Avg struct {
+			SampleInterval float64 `json:"sampleInterval"`
+		} `json:"avg"`
+	} `json:"dosdNetworkAnalyticsAdaptiveGroups"`
+}
+
+type rumDimensions struct {
+	SiteTag     string `json:"siteTag"`
+	RequestPath string `json:"requestPath"`
+	CountryName string `json:"countryName"`
+	DeviceType  string `json:"deviceType"`
+}
+
+type rumAccountResp struct {
+	RUMPerformanceEventsAdaptiveGroups []struct {
+		Count      uint64        `json:"count"`
+		Dimensions rumDimensions `json:"dimensions"`
+	} `json:"rumPerformanceEventsAdaptiveGroups"`
+
+	RUMWebVitalsEventsAdaptiveGroups []struct {
+		Count      uint64        `json:"count"`
+		Dimensions rumDimensions `json:"dimensions"`
+		Quantiles  struct {
+			LargestContentfulPaintP50 float64 `json:"largestContentfulPaintP50"`
+			LargestContentfulPaintP75 float64 `json:"largestContentfulPaintP75"`
+			LargestContentfulPaintP90 float64 `json:"largestContentfulPaintP90"`
+			LargestContentfulPaintP99 float64 `json:"largestContentfulPaintP99"`
+			InteractionToNextPaintP50 float64 `json:"interactionToNextPaintP50"`
+			InteractionToNextPaintP75 float64 `json:"interactionToNextPaintP75"`
+			InteractionToNextPaintP90 float64 `json:"interactionToNextPaintP90"`
+			InteractionToNextPaintP99 float64 `json:"interactionToNextPaintP99"`
+			CumulativeLayoutShiftP50  float64 `json:"cumulativeLayoutShiftP50"`
+			CumulativeLayoutShiftP75  float64 `json:"cumulativeLayoutShiftP75"`
+			CumulativeLayoutShiftP90  float64 `json:"cumulativeLayoutShiftP90"`
+			CumulativeLayoutShiftP99  float64 `json:"cumulativeLayoutShiftP99"`
+			TimeToFirstByteP50        float64 `json:"timeToFirstByteP50"`
+			TimeToFirstByteP75        float64 `json:"timeToFirstByteP75"`
+			TimeToFirstByteP90        float64 `json:"timeToFirstByteP90"`
+			TimeToFirstByteP99        float64 `json:"timeToFirstByteP99"`
+			FirstContentfulPaintP50   float64 `json:"firstContentfulPaintP50"`
+			FirstContentfulPaintP75   float64 `json:"firstContentfulPaintP75"`
+			FirstContentfulPaintP90   float64 `json:"firstContentfulPaintP90"`
+			FirstContentfulPaintP99   float64 `json:"firstContentfulPaintP99"`
+		} `json:"quantiles"`
+	} `json:"rumWebVitalsEventsAdaptiveGroups"`
+}
+
 type cloudflareResponseR2Account struct {
 	Viewer struct {
 		Accounts []r2AccountResp `json:"accounts"`
 	}
 }
 
+type imagesAccountResp struct {
+	ImagesRequestsAdaptiveGroups []struct {
+		Dimensions struct {
+			Variant string `json:"variant"`
+		} `json:"dimensions"`
+		Sum struct {
+			Requests uint64 `json:"requests"`
+		} `json:"sum"`
+	} `json:"imagesRequestsAdaptiveGroups"`
+
+	ImagesUniqueTransformations []struct {
+		Count      uint64 `json:"count"`
+		Dimensions struct {
+			TransformationType string `json:"transformationType"`
+		} `json:"dimensions"`
+	} `json:"imagesUniqueTransformations"`
+}
+
+type cloudflareResponseImagesAccount struct {
+	Viewer struct {
+		Accounts []imagesAccountResp `json:"accounts"`
+	} `json:"viewer"`
+}
+
+type streamAccountResp struct {
+	StreamMinutesViewed []struct {
+		Sum struct {
+			MinutesViewed float64 `json:"minutesViewed"`
+		} `json:"sum"`
+	} `json:"streamMinutesViewed"`
+
+	StreamTopVideos []struct {
+		Count      uint64 `json:"count"`
+		Dimensions struct {
+			UID string `json:"uid"`
+		} `json:"dimensions"`
+		Sum struct {
+			MinutesViewed float64 `json:"minutesViewed"`
+		} `json:"sum"`
+	} `json:"streamTopVideos"`
+}
+
+type cloudflareResponseStreamAccount struct {
+	Viewer struct {
+		Accounts []streamAccountResp `json:"accounts"`
+	} `json:"viewer"`
+}
+
+type turnstileAccountResp struct {
+	TurnstileAdaptiveGroups []struct {
+		Count      uint64 `json:"count"`
+		Dimensions struct {
+			SiteKey   string `json:"siteKey"`
+			Action    string `json:"action"`
+			EventType string `json:"eventType"`
+			ErrorCode string `json:"errorCode"`
+		} `json:"dimensions"`
+	} `json:"turnstileAdaptiveGroups"`
+}
+
+type tunnelAccountResp struct {
+	TunnelGroups []struct {
+		Count      uint64 `json:"count"`
+		Dimensions struct {
+			TunnelID string `json:"tunnelId"`
+		} `json:"dimensions"`
+		Sum struct {
+			RequestBytes  uint64 `json:"requestBytes"`
+			ResponseBytes uint64 `json:"responseBytes"`
+		} `json:"sum"`
+	} `json:"cloudflareTunnelsAnalyticsAdaptiveGroups"`
+}
+
+type cloudflareResponseTunnelAccount struct {
+	Viewer struct {
+		Accounts []tunnelAccountResp `json:"accounts"`
+	} `json:"viewer"`
+}
+
+type cloudflareResponseTurnstileAccount struct {
+	Viewer struct {
+		Accounts []turnstileAccountResp `json:"accounts"`
+	} `json:"viewer"`
+}
+
 type cloudflareResponseLogpushZone struct {
 	Viewer struct {
 		Zones []logpushResponse `json:"zones"`
@@ -96,7 +342,14 @@ type logpushResponse struct {
 			Status          int    `json:"status"`
 			Final           int    `json:"final"`
 		}
+
+		Sum struct {
+			Bytes   uint64 `json:"bytes"`
+			Records uint64 `json:"records"`
+		} `json:"sum"`
 	} `json:"logpushHealthAdaptiveGroups"`
+
+	ZoneTag string `json:"zoneTag"`
 }
 
 type accountResp struct {
@@ -107,9 +360,12 @@ type accountResp struct {
 		}
 
 		Sum struct {
-			Requests uint64  `json:"requests"`
-			Errors   uint64  `json:"errors"`
-			Duration float64 `json:"duration"`
+			Requests    uint64  `json:"requests"`
+			Errors      uint64  `json:"errors"`
+			Subrequests uint64  `json:"subrequests"`
+			Duration    float64 `json:"duration"`
+			CPUTimeUs   float64 `json:"cpuTimeUs"`
+			WallTime    float64 `json:"wallTime"`
 		} `json:"sum"`
 
 		Quantiles struct {
@@ -121,8 +377,48 @@ type accountResp struct {
 			DurationP75  float32 `json:"durationP75"`
 			DurationP99  float32 `json:"durationP99"`
 			DurationP999 float32 `json:"durationP999"`
+			WallTimeP50  float32 `json:"wallTimeP50"`
+			WallTimeP75  float32 `json:"wallTimeP75"`
+			WallTimeP99  float32 `json:"wallTimeP99"`
+			WallTimeP999 float32 `json:"wallTimeP999"`
 		} `json:"quantiles"`
 	} `json:"workersInvocationsAdaptive"`
+
+	AIGatewayRequestsAdaptiveGroups []struct {
+		Count      uint64 `json:"count"`
+		Dimensions struct {
+			Gateway  string `json:"gateway"`
+			Provider string `json:"provider"`
+			Model    string `json:"model"`
+		} `json:"dimensions"`
+
+		Sum struct {
+			CachedRequests    uint64  `json:"cachedRequests"`
+			ErroredRequests   uint64  `json:"erroredRequests"`
+			CachedTokensIn    uint64  `json:"cachedTokensIn"`
+			CachedTokensOut   uint64  `json:"cachedTokensOut"`
+			UncachedTokensIn  uint64  `json:"uncachedTokensIn"`
+			UncachedTokensOut uint64  `json:"uncachedTokensOut"`
+			Cost              float64 `json:"cost"`
+		} `json:"sum"`
+	} `json:"aiGatewayRequestsAdaptiveGroups"`
+
+	AIInferenceAdaptiveGroups []struct {
+		Count      uint64 `json:"count"`
+		Dimensions struct {
+			ModelID string `json:"modelId"`
+		} `json:"dimensions"`
+
+		Sum struct {
+			TotalNeurons float64 `json:"totalNeurons"`
+		} `json:"sum"`
+	} `json:"aiInferenceAdaptiveGroups"`
+
+	WorkersInvocationsScheduled []struct {
+		ScriptName string `json:"scriptName"`
+		Cron       string `json:"cron"`
+		Status     string `json:"status"`
+	} `json:"workersInvocationsScheduled"`
 }
 
 type zoneRespColo struct {
@@ -145,104 +441,368 @@ type zoneRespColo struct {
 	ZoneTag string `json:"zoneTag"`
 }
 
-type zoneResp struct {
-	HTTP1mGroups []struct {
+type zoneRespBots struct {
+	BotGroups []struct {
+		Count      uint64 `json:"count"`
 		Dimensions struct {
-			Datetime string `json:"datetime"`
+			BotScore              uint8  `json:"botScore"`
+			BotManagementDecision string `json:"botManagementDecision"`
+			VerifiedBotCategory   string `json:"verifiedBotCategory"`
+			Host                  string `json:"clientRequestHTTPHost"`
 		} `json:"dimensions"`
-		Unique struct {
-			Uniques uint64 `json:"uniques"`
-		} `json:"uniq"`
-		Sum struct {
-			Bytes          uint64 `json:"bytes"`
-			CachedBytes    uint64 `json:"cachedBytes"`
-			CachedRequests uint64 `json:"cachedRequests"`
-			Requests       uint64 `json:"requests"`
-			BrowserMap     []struct {
-				PageViews       uint64 `json:"pageViews"`
-				UaBrowserFamily string `json:"uaBrowserFamily"`
-			} `json:"browserMap"`
-			ClientHTTPVersion []struct {
-				Protocol string `json:"clientHTTPProtocol"`
-				Requests uint64 `json:"requests"`
-			} `json:"clientHTTPVersionMap"`
-			ClientSSL []struct {
-				Protocol string `json:"clientSSLProtocol"`
-			} `json:"clientSSLMap"`
-			ContentType []struct {
-				Bytes                   uint64 `json:"bytes"`
-				Requests                uint64 `json:"requests"`
-				EdgeResponseContentType string `json:"edgeResponseContentTypeName"`
-			} `json:"contentTypeMap"`
-			Country []struct {
-				Bytes             uint64 `json:"bytes"`
-				ClientCountryName string `json:"clientCountryName"`
-				Requests          uint64 `json:"requests"`
-				Threats           uint64 `json:"threats"`
-			} `json:"countryMap"`
-			EncryptedBytes    uint64 `json:"encryptedBytes"`
-			EncryptedRequests uint64 `json:"encryptedRequests"`
-			IPClass           []struct {
-				Type     string `json:"ipType"`
-				Requests uint64 `json:"requests"`
-			} `json:"ipClassMap"`
-			PageViews      uint64 `json:"pageViews"`
-			ResponseStatus []struct {
-				EdgeResponseStatus int    `json:"edgeResponseStatus"`
-				Requests           uint64 `json:"requests"`
-			} `json:"responseStatusMap"`
-			ThreatPathing []struct {
-				Name     string `json:"threatPathingName"`
-				Requests uint64 `json:"requests"`
-			} `json:"threatPathingMap"`
-			Threats uint64 `json:"threats"`
-		} `json:"sum"`
-	} `json:"httpRequests1mGroups"`
+		Avg struct {
+			SampleInterval float64 `json:"sampleInterval"`
+		} `json:"avg"`
+	} `json:"httpRequestsAdaptiveGroups"`
 
-	FirewallEventsAdaptiveGroups []struct {
+	ZoneTag string `json:"zoneTag"`
+}
+
+type zoneRespArgo struct {
+	ArgoGroups []struct {
 		Count      uint64 `json:"count"`
 		Dimensions struct {
-			Action                string `json:"action"`
-			Source                string `json:"source"`
-			RuleID                string `json:"ruleId"`
-			ClientCountryName     string `json:"clientCountryName"`
-			ClientRequestHTTPHost string `json:"clientRequestHTTPHost"`
+			IsSmartRouted uint8  `json:"isSmartRouted"`
+			OriginIP      string `json:"originIP"`
 		} `json:"dimensions"`
-	} `json:"firewallEventsAdaptiveGroups"`
+		Avg struct {
+			OriginResponseDurationMs float64 `json:"originResponseDurationMs"`
+			SampleInterval           float64 `json:"sampleInterval"`
+		} `json:"avg"`
+		Sum struct {
+			EdgeResponseBytes uint64 `json:"edgeResponseBytes"`
+		} `json:"sum"`
+	} `json:"argoGroups"`
 
-	HTTPRequestsAdaptiveGroups []struct {
+	TieredCacheGroups []struct {
 		Count      uint64 `json:"count"`
 		Dimensions struct {
-			OriginResponseStatus  uint16 `json:"originResponseStatus"`
-			ClientCountryName     string `json:"clientCountryName"`
-			ClientRequestHTTPHost string `json:"clientRequestHTTPHost"`
+			UpperTierColoName string `json:"upperTierColoName"`
+			CacheStatus       string `json:"cacheStatus"`
 		} `json:"dimensions"`
-	} `json:"httpRequestsAdaptiveGroups"`
+		Avg struct {
+			SampleInterval float64 `json:"sampleInterval"`
+		} `json:"avg"`
+	} `json:"tieredCacheGroups"`
 
-	HTTPRequestsEdgeCountryHost []struct {
+	ZoneTag string `json:"zoneTag"`
+}
+
+type cloudflareResponseTopN struct {
+	Viewer struct {
+		Zones []zoneRespTopN `json:"zones"`
+	} `json:"viewer"`
+}
+
+type topNGroup struct {
+	Count      uint64 `json:"count"`
+	Dimensions struct {
+		ClientRequestPath     string `json:"clientRequestPath"`
+		ClientRequestHTTPHost string `json:"clientRequestHTTPHost"`
+		UserAgent             string `json:"userAgent"`
+		ClientAsn             string `json:"clientAsn"`
+		ClientASNDescription  string `json:"clientASNDescription"`
+		ClientIP              string `json:"clientIP"`
+		ClientDeviceType      string `json:"clientDeviceType"`
+	} `json:"dimensions"`
+	Sum struct {
+		EdgeResponseBytes uint64 `json:"edgeResponseBytes"`
+	} `json:"sum"`
+	Avg struct {
+		SampleInterval float64 `json:"sampleInterval"`
+	} `json:"avg"`
+}
+
+type zoneRespTopN struct {
+	TopPaths              []topNGroup `json:"topPaths"`
+	TopHosts              []topNGroup `json:"topHosts"`
+	TopUserAgents         []topNGroup `json:"topUserAgents"`
+	TopASNs               []topNGroup `json:"topASNs"`
+	TopClientIPs          []topNGroup `json:"topClientIPs"`
+	TopDeviceTypes        []topNGroup `json:"topDeviceTypes"`
+	TopPathsByBytes       []topNGroup `json:"topPathsByBytes"`
+	TopHostsByBytes       []topNGroup `json:"topHostsByBytes"`
+	TopUserAgentsByBytes  []topNGroup `json:"topUserAgentsByBytes"`
+	TopASNsByBytes        []topNGroup `json:"topASNsByBytes"`
+	TopClientIPsByBytes   []topNGroup `json:"topClientIPsByBytes"`
+	TopDeviceTypesByBytes []topNGroup `json:"topDeviceTypesByBytes"`
+	TopASNThreats         []topNGroup `json:"topASNThreats"`
+	ZoneTag               string      `json:"zoneTag"`
+}
+
+// Datasets of the top N collector, each is queried in its own request so
+// that an error in one does not lose the others.
+const (
+	topNDatasetHTTP     = "httpRequestsAdaptiveGroups"
+	topNDatasetFirewall = "firewallEventsAdaptiveGroups"
+)
+
+// topNDimension is a dimension of the top N collector, queried as its own
+// alias with the limit set by its flag.
+type topNDimension struct {
+	alias   string
+	fields  string
+	flag    string
+	dataset string
+	filter  string
+}
+
+// HTTP request dimensions are also queried ordered by bytes, as this alias
+// suffix, so that the top N by bytes is not limited to the top N by requests.
+const topNByBytesSuffix = "ByBytes"
+
+// Threats are firewall events that blocked or challenged the request
+const topNThreatsFilter = `action_in: ["block", "challenge", "jschallenge", "managed_challenge", "connection_close"]`
+
+var topNDimensions = []topNDimension{
+	{alias: "topPaths", fields: "clientRequestPath", flag: "top_paths", dataset: topNDatasetHTTP},
+	{alias: "topHosts", fields: "clientRequestHTTPHost", flag: "top_hosts", dataset: topNDatasetHTTP},
+	{alias: "topUserAgents", fields: "userAgent", flag: "top_user_agents", dataset: topNDatasetHTTP},
+	{alias: "topASNs", fields: "clientAsn clientASNDescription", flag: "top_asns", dataset: topNDatasetHTTP},
+	{alias: "topClientIPs", fields: "clientIP", flag: "top_client_ips", dataset: topNDatasetHTTP},
+	{alias: "topDeviceTypes", fields: "clientDeviceType", flag: "top_device_types", dataset: topNDatasetHTTP},
+	{alias: "topASNThreats", fields: "clientAsn clientASNDescription", flag: "top_asns", dataset: topNDatasetFirewall, filter: topNThreatsFilter},
+}
+
+type zoneRespSecurity struct {
+	FirewallEventsAdaptiveGroups []struct {
 		Count      uint64 `json:"count"`
 		Dimensions struct {
-			EdgeResponseStatus    uint16 `json:"edgeResponseStatus"`
-			ClientCountryName     string `json:"clientCountryName"`
-			ClientRequestHTTPHost string `json:"clientRequestHTTPHost"`
+			Action    string `json:"action"`
+			Source    string `json:"source"`
+			RuleID    string `json:"ruleId"`
+			RulesetID string `json:"rulesetId"`
 		} `json:"dimensions"`
-	} `json:"httpRequestsEdgeCountryHost"`
+		Avg struct {
+			SampleInterval float64 `json:"sampleInterval"`
+		} `json:"avg"`
+	} `json:"firewallEventsAdaptiveGroups"`
 
-	HealthCheckEventsAdaptiveGroups []struct {
+	WAFAttackScoreGroups []struct {
 		Count      uint64 `json:"count"`
 		Dimensions struct {
-			HealthStatus  string `json:"healthStatus"`
-			OriginIP      string `json:"originIP"`
-			FailureReason string `json:"failureReason"`
-			Region        string `json:"region"`
-			Fqdn          string `json:"fqdn"`
+			WAFAttackScore uint8 `json:"wafAttackScore"`
 		} `json:"dimensions"`
-	} `json:"healthCheckEventsAdaptiveGroups"`
+		Avg struct {
+			SampleInterval float64 `json:"sampleInterval"`
+		} `json:"avg"`
+	} `json:"wafAttackScoreGroups"`
 
 	ZoneTag string `json:"zoneTag"`
 }
 
-type lbResp struct {
+type zoneRespAPIShield struct {
+	FirewallEventsAdaptiveGroups []struct {
+		Count      uint64 `json:"count"`
+		Dimensions struct {
+			Action                      string `json:"action"`
+			Source                      string `json:"source"`
+			ClientRequestHTTPHost       string `json:"clientRequestHTTPHost"`
+			ClientRequestHTTPMethodName string `json:"clientRequestHTTPMethodName"`
+			ClientRequestPath           string `json:"clientRequestPath"`
+		} `json:"dimensions"`
+		Avg struct {
+			SampleInterval float64 `json:"sampleInterval"`
+		} `json:"avg"`
+	} `json:"firewallEventsAdaptiveGroups"`
+
+	ZoneTag string `json:"zoneTag"`
+}
+
+type zoneRespSpectrum struct {
+	SpectrumNetworkAnalyticsAdaptiveGroups []struct {
+		Dimensions struct {
+			AppID          string `json:"appId"`
+			ColoCode       string `json:"coloCode"`
+			IPProtocolName string `json:"ipProtocolName"`
+		} `json:"dimensions"`
+		Sum struct {
+			Bits    uint64 `json:"bits"`
+			Packets uint64 `json:"packets"`
+		} `json:"sum"`
+		Avg struct {
+			SampleInterval float64 `json:"sampleInterval"`
+		} `json:"avg"`
+	} `json:"spectrumNetworkAnalyticsAdaptiveGroups"`
+
+	ZoneTag string `json:"zoneTag"`
+}
+
+type zoneRespDDoS struct {
+	FirewallEventsAdaptiveGroups []struct {
+		Count      uint64 `json:"count"`
+		Dimensions struct {
+			Action      string `json:"action"`
+			RuleID      string `json:"ruleId"`
+			Description string `json:"description"`
+		} `json:"dimensions"`
+		Avg struct {
+			SampleInterval float64 `json:"sampleInterval"`
+		} `json:"avg"`
+	} `json:"firewallEventsAdaptiveGroups"`
+
+	ZoneTag string `json:"zoneTag"`
+}
+
+type zoneRespWaitingRoom struct {
+	WaitingRoomAnalyticsAdaptiveGroups []struct {
+		Dimensions struct {
+			WaitingRoomID string `json:"waitingRoomId"`
+		} `json:"dimensions"`
+		Max struct {
+			TotalActiveUsers  uint64  `json:"totalActiveUsers"`
+			TotalQueuedUsers  uint64  `json:"totalQueuedUsers"`
+			EstimatedWaitTime float64 `json:"estimatedWaitTime"`
+		} `json:"max"`
+		Avg struct {
+			NewUsersPerMinute float64 `json:"newUsersPerMinute"`
+		} `json:"avg"`
+	} `json:"waitingRoomAnalyticsAdaptiveGroups"`
+
+	ZoneTag string `json:"zoneTag"`
+}
+
+type zoneRespEmailRouting struct {
+	EmailRoutingAdaptiveGroups []struct {
+		Count      uint64 `json:"count"`
+		Dimensions struct {
+			Action      string `json:"action"`
+			Status      string `json:"status"`
+			RuleMatched string `json:"ruleMatched"`
+			SPF         string `json:"spf"`
+			DKIM        string `json:"dkim"`
+			DMARC       string `json:"dmarc"`
+		} `json:"dimensions"`
+		Avg struct {
+			SampleInterval float64 `json:"sampleInterval"`
+		} `json:"avg"`
+	} `json:"emailRoutingAdaptiveGroups"`
+
+	ZoneTag string `json:"zoneTag"`
+}
+
+type zoneResp struct {
+	HTTP1mGroups []struct {
+		Dimensions struct {
+			Datetime string `json:"datetime"`
+		} `json:"dimensions"`
+		Unique struct {
+			Uniques uint64 `json:"uniques"`
+		} `json:"uniq"`
+		Sum struct {
+			Bytes          uint64 `json:"bytes"`
+			CachedBytes    uint64 `json:"cachedBytes"`
+			CachedRequests uint64 `json:"cachedRequests"`
+			Requests       uint64 `json:"requests"`
+			BrowserMap     []struct {
+				PageViews       uint64 `json:"pageViews"`
+				UaBrowserFamily string `json:"uaBrowserFamily"`
+			} `json:"browserMap"`
+			ClientHTTPVersion []struct {
+				Protocol string `json:"clientHTTPProtocol"`
+				Requests uint64 `json:"requests"`
+			} `json:"clientHTTPVersionMap"`
+			ClientSSL []struct {
+				Protocol string `json:"clientSSLProtocol"`
+			} `json:"clientSSLMap"`
+			ContentType []struct {
+				Bytes                   uint64 `json:"bytes"`
+				Requests                uint64 `json:"requests"`
+				EdgeResponseContentType string `json:"edgeResponseContentTypeName"`
+			} `json:"contentTypeMap"`
+			Country []struct {
+				Bytes             uint64 `json:"bytes"`
+				ClientCountryName string `json:"clientCountryName"`
+				Requests          uint64 `json:"requests"`
+				Threats           uint64 `json:"threats"`
+			} `json:"countryMap"`
+			EncryptedBytes    uint64 `json:"encryptedBytes"`
+			EncryptedRequests uint64 `json:"encryptedRequests"`
+			IPClass           []struct {
+				Type     string `json:"ipType"`
+				Requests uint64 `json:"requests"`
+			} `json:"ipClassMap"`
+			PageViews      uint64 `json:"pageViews"`
+			ResponseStatus []struct {
+				EdgeResponseStatus int    `json:"edgeResponseStatus"`
+				Requests           uint64 `json:"requests"`
+			} `json:"responseStatusMap"`
+			ThreatPathing []struct {
+				Name     string `json:"threatPathingName"`
+				Requests uint64 `json:"requests"`
+			} `json:"threatPathingMap"`
+			Threats uint64 `json:"threats"`
+		} `json:"sum"`
+	} `json:"httpRequests1mGroups"`
+
+	FirewallEventsAdaptiveGroups []struct {
+		Count      uint64 `json:"count"`
+		Dimensions struct {
+			Action                string `json:"action"`
+			Source                string `json:"source"`
+			RuleID                string `json:"ruleId"`
+			ClientCountryName     string `json:"clientCountryName"`
+			ClientRequestHTTPHost string `json:"clientRequestHTTPHost"`
+		} `json:"dimensions"`
+		Avg struct {
+			SampleInterval float64 `json:"sampleInterval"`
+		} `json:"avg"`
+	} `json:"firewallEventsAdaptiveGroups"`
+
+	HTTPRequestsAdaptiveGroups []struct {
+		Count      uint64 `json:"count"`
+		Dimensions struct {
+			OriginResponseStatus  uint16 `json:"originResponseStatus"`
+			ClientCountryName     string `json:"clientCountryName"`
+			ClientRequestHTTPHost string `json:"clientRequestHTTPHost"`
+		} `json:"dimensions"`
+		Avg struct {
+			SampleInterval float64 `json:"sampleInterval"`
+		} `json:"avg"`
+	} `json:"httpRequestsAdaptiveGroups"`
+
+	HTTPRequestsEdgeCountryHost []struct {
+		Count      uint64 `json:"count"`
+		Dimensions struct {
+			EdgeResponseStatus    uint16 `json:"edgeResponseStatus"`
+			ClientCountryName     string `json:"clientCountryName"`
+			ClientRequestHTTPHost string `json:"clientRequestHTTPHost"`
+		} `json:"dimensions"`
+		Avg struct {
+			SampleInterval float64 `json:"sampleInterval"`
+		} `json:"avg"`
+	} `json:"httpRequestsEdgeCountryHost"`
+
+	HealthCheckEventsAdaptiveGroups []struct {
+		Count      uint64 `json:"count"`
+		Dimensions struct {
+			HealthStatus  string `json:"healthStatus"`
+			OriginIP      string `json:"originIP"`
+			FailureReason string `json:"failureReason"`
+			Region        string `json:"region"`
+			Fqdn          string `json:"fqdn"`
+		} `json:"dimensions"`
+	} `json:"healthCheckEventsAdaptiveGroups"`
+
+	ZoneTag string `json:"zoneTag"`
+}
+
+// securityRuleset is a ruleset together with its rules, as resolved through the
+// rulesets API. Rules are keyed by rule ID.
+type securityRuleset struct {
+	ID      string
+	Name    string
+	Kind    string
+	Phase   string
+	Version string
+	Rules   map[string]string
+	// DeploysOWASP is set when the ruleset executes the OWASP Core Ruleset,
+	// OWASPScoreThreshold is its anomaly score override, 0 for the default.
+	DeploysOWASP        bool
+	OWASPScoreThreshold int64
+}
+
+type lbResp struct {
 	LoadBalancingRequestsAdaptiveGroups []struct {
 		Count      uint64 `json:"count"`
 		Dimensions struct {
@@ -255,6 +815,9 @@ type lbResp struct {
 			SelectedPoolName     string `json:"selectedPoolName"`
 			SteeringPolicy       string `json:"steeringPolicy"`
 		} `json:"dimensions"`
+		Avg struct {
+			SampleInterval float64 `json:"sampleInterval"`
+		} `json:"avg"`
 	} `json:"loadBalancingRequestsAdaptiveGroups"`
 
 	LoadBalancingRequestsAdaptive []struct {
@@ -435,6 +998,107 @@ func fetchFirewallRules(zoneID string) map[string]string {
 	return firewallRulesMap
 }
 
+var (
+	securityRulesetPhases = []cfrulesets.Phase{
+		cfrulesets.PhaseHTTPRequestFirewallManaged,
+		cfrulesets.PhaseHTTPRequestFirewallCustom,
+		cfrulesets.PhaseHTTPRatelimit,
+	}
+
+	// Rulesets by zone and ruleset ID. They are only fetched again when
+	// their version changes, managed rulesets contain thousands of rules.
+	securityRulesetCache   = map[string]map[string]securityRuleset{}
+	securityRulesetCacheMu sync.Mutex
+)
+
+// pruneSecurityRulesetCache forgets the rulesets of zones that are no longer
+// scraped.
+func pruneSecurityRulesetCache(zones []cfzones.Zone) {
+	current := make(map[string]struct{}, len(zones))
+	for _, z := range zones {
+		current[z.ID] = struct{}{}
+	}
+
+	securityRulesetCacheMu.Lock()
+	defer securityRulesetCacheMu.Unlock()
+	for zoneID := range securityRulesetCache {
+		if _, exists := current[zoneID]; !exists {
+			delete(securityRulesetCache, zoneID)
+		}
+	}
+}
+
+func fetchSecurityRulesets(zoneID string) []securityRuleset {
+	listOfRulesets, err := getRuleSetsList(cfrulesets.RulesetListParams{
+		ZoneID: cf.F(zoneID),
+	})
+	if err != nil {
+		log.Errorf("error fetching security rulesets, ZoneID:%s, Err:%v", zoneID, err)
+		return nil
+	}
+
+	securityRulesetCacheMu.Lock()
+	cachedRulesets := securityRulesetCache[zoneID]
+	securityRulesetCacheMu.Unlock()
+
+	var rulesets []securityRuleset
+	// Only the rulesets still deployed are kept in the cache
+	fetched := make(map[string]securityRuleset)
+	for _, rulesetDesc := range listOfRulesets {
+		if !slices.Contains(securityRulesetPhases, rulesetDesc.Phase) {
+			continue
+		}
+
+		if cached, exists := cachedRulesets[rulesetDesc.ID]; exists && cached.Version == rulesetDesc.Version {
+			fetched[cached.ID] = cached
+			rulesets = append(rulesets, cached)
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+		ruleset, err := cfclient.Rulesets.Get(ctx, rulesetDesc.ID, cfrulesets.RulesetGetParams{
+			ZoneID: cf.F(zoneID),
+		})
+		cancel()
+		if err != nil {
+			log.Errorf("error fetching security ruleset, ZoneID:%s, RulesetID:%s, Err:%v", zoneID, rulesetDesc.ID, err)
+			continue
+		}
+
+		rs := securityRuleset{
+			ID:      ruleset.ID,
+			Name:    ruleset.Name,
+			Kind:    string(ruleset.Kind),
+			Phase:   string(ruleset.Phase),
+			Version: ruleset.Version,
+			Rules:   make(map[string]string, len(ruleset.Rules)),
+		}
+		for _, rule := range ruleset.Rules {
+			rs.Rules[rule.ID] = rule.Description
+
+			execute, ok := rule.AsUnion().(cfrulesets.ExecuteRule)
+			if !ok || execute.ActionParameters.ID != owaspManagedRulesetID {
+				continue
+			}
+			rs.DeploysOWASP = true
+			for _, override := range execute.ActionParameters.Overrides.Rules {
+				if override.ScoreThreshold > 0 {
+					rs.OWASPScoreThreshold = override.ScoreThreshold
+				}
+			}
+		}
+
+		fetched[rs.ID] = rs
+		rulesets = append(rulesets, rs)
+	}
+
+	securityRulesetCacheMu.Lock()
+	securityRulesetCache[zoneID] = fetched
+	securityRulesetCacheMu.Unlock()
+
+	return rulesets
+}
+
 func fetchAccounts() []cfaccounts.Account {
 	var cfAccounts []cfaccounts.Account
 	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
@@ -533,6 +1197,9 @@ query ($zoneIDs: [String!], $mintime: Time!, $maxtime: Time!, $limit: Int!) {
 				  clientRequestHTTPHost
 				  clientCountryName
 				}
+				avg {
+					sampleInterval
+				}
 			}
 			httpRequestsAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime, cacheStatus_notin: ["hit"] }) {
 				count
@@ -541,6 +1208,9 @@ query ($zoneIDs: [String!], $mintime: Time!, $maxtime: Time!, $limit: Int!) {
 					clientCountryName
 					clientRequestHTTPHost
 				}
+				avg {
+					sampleInterval
+				}
 			}
 			httpRequestsEdgeCountryHost: httpRequestsAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime, requestSource_in: ["eyeball"] }) {
 				count
@@ -549,6 +1219,9 @@ query ($zoneIDs: [String!], $mintime: Time!, $maxtime: Time!, $limit: Int!) {
 					clientCountryName
 					clientRequestHTTPHost
 				}
+				avg {
+					sampleInterval
+				}
 			}
 			healthCheckEventsAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime }) {
 				count
@@ -635,44 +1308,37 @@ func fetchColoTotals(zoneIDs []string) (*cloudflareResponseColo, error) {
 	return &resp, nil
 }
 
-func fetchWorkerTotals(accountID string) (*cloudflareResponseAccts, error) {
+func fetchBotTotals(zoneIDs []string) (*cloudflareResponseBots, error) {
 	request := graphql.NewRequest(`
-	query ($accountID: String!, $mintime: Time!, $maxtime: Time!, $limit: Int!) {
+	query ($zoneIDs: [String!], $mintime: Time!, $maxtime: Time!, $limit: Int!) {
 		viewer {
-			accounts(filter: {accountTag: $accountID} ) {
-				workersInvocationsAdaptive(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime}) {
-					dimensions {
-						scriptName
-						status
-					}
-
-					sum {
-						requests
-						errors
-						duration
-					}
-
-					quantiles {
-						cpuTimeP50
-						cpuTimeP75
-						cpuTimeP99
-						cpuTimeP999
-						durationP50
-						durationP75
-						durationP99
-						durationP999
+			zones(filter: { zoneTag_in: $zoneIDs }) {
+				zoneTag
+				httpRequestsAdaptiveGroups(
+					limit: $limit
+					filter: { datetime_geq: $mintime, datetime_lt: $maxtime, requestSource_in: ["eyeball"] }
+					) {
+						count
+						dimensions {
+							botScore
+							botManagementDecision
+							verifiedBotCategory
+							clientRequestHTTPHost
+						}
+						avg {
+							sampleInterval
+						}
 					}
 				}
 			}
 		}
-	}
 `)
 
 	now, now1mAgo := GetTimeRange()
 	request.Var("limit", gqlQueryLimit)
 	request.Var("maxtime", now)
 	request.Var("mintime", now1mAgo)
-	request.Var("accountID", accountID)
+	request.Var("zoneIDs", zoneIDs)
 
 	gql.Mu.RLock()
 	defer gql.Mu.RUnlock()
@@ -680,285 +1346,1700 @@ func fetchWorkerTotals(accountID string) (*cloudflareResponseAccts, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
 	defer cancel()
 
-	var resp cloudflareResponseAccts
+	var resp cloudflareResponseBots
 	if err := gql.Client.Run(ctx, request, &resp); err != nil {
-		log.Errorf("error fetching worker totals, err:%v", err)
+		log.Errorf("failed to fetch bot management totals, err:%v", err)
 		return nil, err
 	}
 
 	return &resp, nil
 }
 
-func fetchLoadBalancerTotals(zoneIDs []string) (*cloudflareResponseLb, error) {
+func fetchArgoTotals(zoneIDs []string) (*cloudflareResponseArgo, error) {
 	request := graphql.NewRequest(`
 	query ($zoneIDs: [String!], $mintime: Time!, $maxtime: Time!, $limit: Int!) {
 		viewer {
 			zones(filter: { zoneTag_in: $zoneIDs }) {
 				zoneTag
-				loadBalancingRequestsAdaptiveGroups(
-					filter: { datetime_geq: $mintime, datetime_lt: $maxtime},
-					limit: $limit) {
+				argoGroups: httpRequestsAdaptiveGroups(
+					limit: $limit
+					filter: { datetime_geq: $mintime, datetime_lt: $maxtime, requestSource_in: ["eyeball"], originIP_neq: "" }
+					) {
+						count
+						dimensions {
+							isSmartRouted
+							originIP
+						}
+						avg {
+							originResponseDurationMs
+							sampleInterval
+						}
+						sum {
+							edgeResponseBytes
+						}
+					}
+				tieredCacheGroups: httpRequestsAdaptiveGroups(
+					limit: $limit
+					filter: { datetime_geq: $mintime, datetime_lt: $maxtime, requestSource_in: ["eyeball"], upperTierColoName_neq: "" }
+					) {
+						count
+						dimensions {
+							upperTierColoName
+							cacheStatus
+						}
+						avg {
+							sampleInterval
+						}
+					}
+				}
+			}
+		}
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("zoneIDs", zoneIDs)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseArgo
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("failed to fetch argo totals, err:%v", err)
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// fetchTopNTotals fetches the top N values by requests, and for HTTP
+// requests also by bytes, of every dimension with a limit above zero, limits
+// are keyed by dimension alias.
+func fetchTopNTotals(zoneIDs []string, dataset string, limits map[string]int) (*cloudflareResponseTopN, error) {
+	var groups strings.Builder
+	for _, d := range topNDimensions {
+		limit := limits[d.alias]
+		if d.dataset != dataset || limit <= 0 {
+			continue
+		}
+		filter, sum := `requestSource_in: ["eyeball"]`, `
+						sum {
+							edgeResponseBytes
+						}`
+		orders := map[string]string{d.alias: "count_DESC", d.alias + topNByBytesSuffix: "sum_edgeResponseBytes_DESC"}
+		if dataset == topNDatasetFirewall {
+			// Firewall events have no byte counts
+			filter, sum = d.filter, ""
+			orders = map[string]string{d.alias: "count_DESC"}
+		}
+		for alias, orderBy := range orders {
+			fmt.Fprintf(&groups, `
+				%s: %s(
+					limit: %d
+					filter: { datetime_geq: $mintime, datetime_lt: $maxtime, %s }
+					orderBy: [%s]
+					) {
+						count
+						dimensions {
+							%s
+						}
+						avg {
+							sampleInterval
+						}%s
+					}`, alias, d.dataset, limit, filter, orderBy, d.fields, sum)
+		}
+	}
+
+	var resp cloudflareResponseTopN
+	if groups.Len() == 0 {
+		return &resp, nil
+	}
+
+	request := graphql.NewRequest(`
+	query ($zoneIDs: [String!], $mintime: Time!, $maxtime: Time!) {
+		viewer {
+			zones(filter: { zoneTag_in: $zoneIDs }) {
+				zoneTag` + groups.String() + `
+				}
+			}
+		}
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("zoneIDs", zoneIDs)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("failed to fetch top n totals from %s, err:%v", dataset, err)
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func fetchSecurityTotals(zoneIDs []string) (*cloudflareResponseSecurity, error) {
+	request := graphql.NewRequest(`
+	query ($zoneIDs: [String!], $mintime: Time!, $maxtime: Time!, $limit: Int!) {
+		viewer {
+			zones(filter: { zoneTag_in: $zoneIDs }) {
+				zoneTag
+				firewallEventsAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime }) {
 					count
 					dimensions {
-						region
-						lbName
-						selectedPoolName
-						proxied
-						selectedOriginName
-						selectedPoolAvgRttMs
-						selectedPoolHealthy
-						steeringPolicy
+						action
+						source
+						ruleId
+						rulesetId
+					}
+					avg {
+						sampleInterval
 					}
 				}
-				loadBalancingRequestsAdaptive(
-					filter: { datetime_geq: $mintime, datetime_lt: $maxtime},
-					limit: $limit) {
-					lbName
-					proxied
-					region
-					selectedPoolHealthy
-					selectedPoolId
-					selectedPoolName
-					sessionAffinityStatus
-					steeringPolicy
-					selectedPoolAvgRttMs
-					pools {
-						id
-						poolName
-						healthy
-						avgRttMs
+				wafAttackScoreGroups: httpRequestsAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime, requestSource_in: ["eyeball"] }) {
+					count
+					dimensions {
+						wafAttackScore
 					}
-					origins {
-						originName
-						health
-						ipv4
-						selected
+					avg {
+						sampleInterval
+					}
+				}
+			}
+		}
+	}
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("zoneIDs", zoneIDs)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseSecurity
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("failed to fetch security totals, err:%v", err)
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func fetchAPIShieldTotals(zoneIDs []string) (*cloudflareResponseAPIShield, error) {
+	request := graphql.NewRequest(`
+	query ($zoneIDs: [String!], $mintime: Time!, $maxtime: Time!, $limit: Int!) {
+		viewer {
+			zones(filter: { zoneTag_in: $zoneIDs }) {
+				zoneTag
+				firewallEventsAdaptiveGroups(limit: $limit, filter: {
+					datetime_geq: $mintime,
+					datetime_lt: $maxtime,
+					source_in: ["apiShieldSchemaValidation", "apiShieldSequenceMitigation"]
+				}) {
+					count
+					dimensions {
+						action
+						source
+						clientRequestHTTPHost
+						clientRequestHTTPMethodName
+						clientRequestPath
+					}
+					avg {
+						sampleInterval
+					}
+				}
+			}
+		}
+	}
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("zoneIDs", zoneIDs)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseAPIShield
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("failed to fetch API Shield totals, err:%v", err)
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func fetchSpectrumTotals(zoneIDs []string) (*cloudflareResponseSpectrum, error) {
+	request := graphql.NewRequest(`
+	query ($zoneIDs: [String!], $mintime: Time!, $maxtime: Time!, $limit: Int!) {
+		viewer {
+			zones(filter: { zoneTag_in: $zoneIDs }) {
+				zoneTag
+				spectrumNetworkAnalyticsAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime }) {
+					dimensions {
+						appId
+						coloCode
+						ipProtocolName
+					}
+					sum {
+						bits
+						packets
+					}
+					avg {
+						sampleInterval
+					}
+				}
+			}
+		}
+	}
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("zoneIDs", zoneIDs)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseSpectrum
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("failed to fetch spectrum totals, err:%v", err)
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func fetchMagicTransitTotals(accountID string) (*cloudflareResponseMagicTransit, error) {
+	request := graphql.NewRequest(`
+	query ($accountID: String!, $mintime: Time!, $maxtime: Time!, $limit: Int!) {
+		viewer {
+			accounts(filter: {accountTag: $accountID} ) {
+				magicTransitNetworkAnalyticsAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime }) {
+					dimensions {
+						ipDestinationSubnet
+						ipProtocolName
+						coloCode
+						outcome
+					}
+					sum {
+						bits
+						packets
+					}
+					avg {
+						sampleInterval
 					}
 				}
 			}
 		}
 	}
-`)
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("accountID", accountID)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseMagicTransit
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func fetchDDoSTotals(zoneIDs []string) (*cloudflareResponseDDoS, error) {
+	request := graphql.NewRequest(`
+	query ($zoneIDs: [String!], $mintime: Time!, $maxtime: Time!, $limit: Int!) {
+		viewer {
+			zones(filter: { zoneTag_in: $zoneIDs }) {
+				zoneTag
+				firewallEventsAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime, source: "l7ddos" }) {
+					count
+					dimensions {
+						action
+						ruleId
+						description
+					}
+					avg {
+						sampleInterval
+					}
+				}
+			}
+		}
+	}
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("zoneIDs", zoneIDs)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseDDoS
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("failed to fetch DDoS totals, err:%v", err)
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func fetchDDoSAccount(accountID string) (*cloudflareResponseDDoSAccount, error) {
+	request := graphql.NewRequest(`
+	query ($accountID: String!, $mintime: Time!, $maxtime: Time!, $limit: Int!) {
+		viewer {
+			accounts(filter: {accountTag: $accountID} ) {
+				dosdNetworkAnalyticsAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime }) {
+					dimensions {
+						attackId
+						attackVector
+						ruleId
+						outcome
+					}
+					sum {
+						bits
+						packets
+					}
+					avg {
+						sampleInterval
+					}
+				}
+			}
+		}
+	}
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("accountID", accountID)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseDDoSAccount
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func fetchRUMTotals(accountID string) (*cloudflareResponseRUM, error) {
+	request := graphql.NewRequest(`
+	query ($accountID: String!, $mintime: Time!, $maxtime: Time!, $limit: Int!) {
+		viewer {
+			accounts(filter: {accountTag: $accountID} ) {
+				rumPerformanceEventsAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime }) {
+					count
+					dimensions {
+						siteTag
+						requestPath
+						countryName
+						deviceType
+					}
+				}
+				rumWebVitalsEventsAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime }) {
+					count
+					dimensions {
+						siteTag
+						requestPath
+						countryName
+						deviceType
+					}
+					quantiles {
+						largestContentfulPaintP50
+						largestContentfulPaintP75
+						largestContentfulPaintP90
+						largestContentfulPaintP99
+						interactionToNextPaintP50
+						interactionToNextPaintP75
+						interactionToNextPaintP90
+						interactionToNextPaintP99
+						cumulativeLayoutShiftP50
+						cumulativeLayoutShiftP75
+						cumulativeLayoutShiftP90
+						cumulativeLayoutShiftP99
+						timeToFirstByteP50
+						timeToFirstByteP75
+						timeToFirstByteP90
+						timeToFirstByteP99
+						firstContentfulPaintP50
+						firstContentfulPaintP75
+						firstContentfulPaintP90
+						firstContentfulPaintP99
+					}
+				}
+			}
+		}
+	}
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("accountID", accountID)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseRUM
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("error fetching web analytics totals, err:%v", err)
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func fetchWaitingRoomTotals(zoneIDs []string) (*cloudflareResponseWaitingRoom, error) {
+	request := graphql.NewRequest(`
+	query ($zoneIDs: [String!], $mintime: Time!, $maxtime: Time!, $limit: Int!) {
+		viewer {
+			zones(filter: { zoneTag_in: $zoneIDs }) {
+				zoneTag
+				waitingRoomAnalyticsAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime }) {
+					dimensions {
+						waitingRoomId
+					}
+					max {
+						totalActiveUsers
+						totalQueuedUsers
+						estimatedWaitTime
+					}
+					avg {
+						newUsersPerMinute
+					}
+				}
+			}
+		}
+	}
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("zoneIDs", zoneIDs)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseWaitingRoom
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("failed to fetch waiting room totals, err:%v", err)
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func fetchEmailRoutingTotals(zoneIDs []string) (*cloudflareResponseEmailRouting, error) {
+	request := graphql.NewRequest(`
+	query ($zoneIDs: [String!], $mintime: Time!, $maxtime: Time!, $limit: Int!) {
+		viewer {
+			zones(filter: { zoneTag_in: $zoneIDs }) {
+				zoneTag
+				emailRoutingAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime }) {
+					count
+					dimensions {
+						action
+						status
+						ruleMatched
+						spf
+						dkim
+						dmarc
+					}
+					avg {
+						sampleInterval
+					}
+				}
+			}
+		}
+	}
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("zoneIDs", zoneIDs)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseEmailRouting
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("failed to fetch email routing totals, err:%v", err)
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func fetchWorkerTotals(accountID string) (*cloudflareResponseAccts, error) {
+	request := graphql.NewRequest(`
+	query ($accountID: String!, $mintime: Time!, $maxtime: Time!, $limit: Int!) {
+		viewer {
+			accounts(filter: {accountTag: $accountID} ) {
+				workersInvocationsAdaptive(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime}) {
+					dimensions {
+						scriptName
+						status
+					}
+
+					sum {
+						requests
+						errors
+						subrequests
+						duration
+						cpuTimeUs
+						wallTime
+					}
+
+					quantiles {
+						cpuTimeP50
+						cpuTimeP75
+						cpuTimeP99
+						cpuTimeP999
+						durationP50
+						durationP75
+						durationP99
+						durationP999
+						wallTimeP50
+						wallTimeP75
+						wallTimeP99
+						wallTimeP999
+					}
+				}
+			}
+		}
+	}
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("accountID", accountID)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseAccts
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("error fetching worker totals, err:%v", err)
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func fetchWorkerCronTotals(accountID string) (*cloudflareResponseAccts, error) {
+	request := graphql.NewRequest(`
+	query ($accountID: String!, $mintime: Time!, $maxtime: Time!, $limit: Int!) {
+		viewer {
+			accounts(filter: {accountTag: $accountID} ) {
+				workersInvocationsScheduled(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime}) {
+					scriptName
+					cron
+					status
+				}
+			}
+		}
+	}
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("accountID", accountID)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseAccts
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("error fetching worker cron totals, err:%v", err)
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func fetchAITotals(accountID string) (*cloudflareResponseAccts, error) {
+	request := graphql.NewRequest(`
+	query ($accountID: String!, $mintime: Time!, $maxtime: Time!, $limit: Int!) {
+		viewer {
+			accounts(filter: {accountTag: $accountID} ) {
+				aiGatewayRequestsAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime}) {
+					count
+					dimensions {
+						gateway
+						provider
+						model
+					}
+
+					sum {
+						cachedRequests
+						erroredRequests
+						cachedTokensIn
+						cachedTokensOut
+						uncachedTokensIn
+						uncachedTokensOut
+						cost
+					}
+				}
+
+				aiInferenceAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime}) {
+					count
+					dimensions {
+						modelId
+					}
+
+					sum {
+						totalNeurons
+					}
+				}
+			}
+		}
+	}
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("accountID", accountID)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseAccts
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("error fetching AI totals, err:%v", err)
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func fetchLoadBalancerTotals(zoneIDs []string) (*cloudflareResponseLb, error) {
+	request := graphql.NewRequest(`
+	query ($zoneIDs: [String!], $mintime: Time!, $maxtime: Time!, $limit: Int!) {
+		viewer {
+			zones(filter: { zoneTag_in: $zoneIDs }) {
+				zoneTag
+				loadBalancingRequestsAdaptiveGroups(
+					filter: { datetime_geq: $mintime, datetime_lt: $maxtime},
+					limit: $limit) {
+					count
+					dimensions {
+						region
+						lbName
+						selectedPoolName
+						proxied
+						selectedOriginName
+						selectedPoolAvgRttMs
+						selectedPoolHealthy
+						steeringPolicy
+					}
+					avg {
+						sampleInterval
+					}
+				}
+				loadBalancingRequestsAdaptive(
+					filter: { datetime_geq: $mintime, datetime_lt: $maxtime},
+					limit: $limit) {
+					lbName
+					proxied
+					region
+					selectedPoolHealthy
+					selectedPoolId
+					selectedPoolName
+					sessionAffinityStatus
+					steeringPolicy
+					selectedPoolAvgRttMs
+					pools {
+						id
+						poolName
+						healthy
+						avgRttMs
+					}
+					origins {
+						originName
+						health
+						ipv4
+						selected
+					}
+				}
+			}
+		}
+	}
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("zoneIDs", zoneIDs)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseLb
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("error fetching load balancer totals, err:%v", err)
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func fetchLogpushAccount(accountID string) (*cloudflareResponseLogpushAccount, error) {
+	request := graphql.NewRequest(`query($accountID: String!, $limit: Int!, $mintime: Time!, $maxtime: Time!) {
+		viewer {
+		  accounts(filter: {accountTag : $accountID }) {
+			logpushHealthAdaptiveGroups(
+			  filter: {
+				datetime_geq: $mintime
+				datetime_lt: $maxtime
+			  }
+			  limit: $limit
+			) {
+			  count
+			  dimensions {
+				jobId
+				status
+				destinationType
+				datetime
+				final
+			  }
+			  sum {
+				bytes
+				records
+			  }
+			}
+		  }
+		}
+	  }`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("accountID", accountID)
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	var resp cloudflareResponseLogpushAccount
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("error fetching logpush account totals, err:%v", err)
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func fetchLogpushZone(zoneIDs []string) (*cloudflareResponseLogpushZone, error) {
+	request := graphql.NewRequest(`query($zoneIDs: [String!], $limit: Int!, $mintime: Time!, $maxtime: Time!) {
+		viewer {
+			zones(filter: {zoneTag_in : $zoneIDs }) {
+			zoneTag
+			logpushHealthAdaptiveGroups(
+			  filter: {
+				datetime_geq: $mintime
+				datetime_lt: $maxtime
+			  }
+			  limit: $limit
+			) {
+			  count
+			  dimensions {
+				jobId
+				status
+				destinationType
+				datetime
+				final
+			  }
+			  sum {
+				bytes
+				records
+			  }
+			}
+		  }
+		}
+	  }`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("zoneIDs", zoneIDs)
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseLogpushZone
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("error fetching logpush zone totals, err:%v", err)
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func fetchR2Account(accountID string) (*cloudflareResponseR2Account, error) {
+	request := graphql.NewRequest(`query($accountID: String!, $limit: Int!, $date: String!, $mintime: Time!, $maxtime: Time!) {
+		viewer {
+		  accounts(filter: {accountTag : $accountID }) {
+			r2StorageAdaptiveGroups(
+			  filter: {
+				date: $date
+			  },
+			  limit: $limit
+			) {
+			  dimensions {
+          		bucketName
+				storageClass
+			  }
+        	  max {
+				metadataSize
+          		payloadSize
+				objectCount
+			  }
+      		}
+			r2OperationsAdaptiveGroups(filter: { datetime_geq: $mintime, datetime_lt: $maxtime }, limit: $limit) {
+				dimensions {
+					actionType
+					bucketName
+					storageClass
+					responseStatusCode
+				}
+				sum {
+					requests
+					responseBytes
+				}
+			}
+			}
+		  }
+	  }`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("accountID", accountID)
+	request.Var("limit", gqlQueryLimit)
+	request.Var("date", now.Format("2006-01-02"))
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseR2Account
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("error fetching R2 account: %v", err)
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func fetchImagesAccount(accountID string) (*cloudflareResponseImagesAccount, error) {
+	request := graphql.NewRequest(`query($accountID: String!, $limit: Int!, $date: String!) {
+		viewer {
+			accounts(filter: {accountTag : $accountID }) {
+				imagesRequestsAdaptiveGroups(filter: { date: $date }, limit: $limit) {
+					dimensions {
+						variant
+					}
+					sum {
+						requests
+					}
+				}
+				imagesUniqueTransformations(filter: { date: $date }, limit: $limit) {
+					count
+					dimensions {
+						transformationType
+					}
+				}
+			}
+		}
+	}`)
+
+	now, _ := GetTimeRange()
+	request.Var("accountID", accountID)
+	request.Var("limit", gqlQueryLimit)
+	request.Var("date", now.Format("2006-01-02"))
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseImagesAccount
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("error fetching images account: %v", err)
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func fetchStreamAccount(accountID string, topVideos int) (*cloudflareResponseStreamAccount, error) {
+	request := graphql.NewRequest(`query($accountID: String!, $limit: Int!, $topVideos: Int!, $date: String!) {
+		viewer {
+			accounts(filter: {accountTag : $accountID }) {
+				streamMinutesViewed: streamMinutesViewedAdaptiveGroups(filter: { date: $date }, limit: $limit) {
+					sum {
+						minutesViewed
+					}
+				}
+				streamTopVideos: streamMinutesViewedAdaptiveGroups(filter: { date: $date }, limit: $topVideos, orderBy: [sum_minutesViewed_DESC]) {
+					count
+					dimensions {
+						uid
+					}
+					sum {
+						minutesViewed
+					}
+				}
+			}
+		}
+	}`)
+
+	now, _ := GetTimeRange()
+	request.Var("accountID", accountID)
+	request.Var("limit", gqlQueryLimit)
+	request.Var("topVideos", topVideos)
+	request.Var("date", now.Format("2006-01-02"))
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseStreamAccount
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("error fetching stream account: %v", err)
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func fetchTurnstileAccount(accountID string) (*cloudflareResponseTurnstileAccount, error) {
+	request := graphql.NewRequest(`
+	query ($accountID: String!, $mintime: Time!, $maxtime: Time!, $limit: Int!) {
+		viewer {
+			accounts(filter: {accountTag: $accountID} ) {
+				turnstileAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime }) {
+					count
+					dimensions {
+						siteKey
+						action
+						eventType
+						errorCode
+					}
+				}
+			}
+		}
+	}
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("accountID", accountID)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseTurnstileAccount
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("error fetching turnstile account: %v", err)
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func fetchTunnelTotals(accountID string) (*cloudflareResponseTunnelAccount, error) {
+	request := graphql.NewRequest(`
+	query ($accountID: String!, $mintime: Time!, $maxtime: Time!, $limit: Int!) {
+		viewer {
+			accounts(filter: {accountTag: $accountID} ) {
+				cloudflareTunnelsAnalyticsAdaptiveGroups(limit: $limit, filter: { datetime_geq: $mintime, datetime_lt: $maxtime }) {
+					count
+					dimensions {
+						tunnelId
+					}
+					sum {
+						requestBytes
+						responseBytes
+					}
+				}
+			}
+		}
+	}
+`)
+
+	now, now1mAgo := GetTimeRange()
+	request.Var("limit", gqlQueryLimit)
+	request.Var("maxtime", now)
+	request.Var("mintime", now1mAgo)
+	request.Var("accountID", accountID)
+
+	gql.Mu.RLock()
+	defer gql.Mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+
+	var resp cloudflareResponseTunnelAccount
+	if err := gql.Client.Run(ctx, request, &resp); err != nil {
+		log.Errorf("error fetching tunnel totals, err:%v", err)
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func fetchTurnstileWidgets(accountID string) []cfturnstile.WidgetListResponse {
+	// Non-nil when the list succeeds, so callers can tell an empty account from an error
+	cfWidgets := []cfturnstile.WidgetListResponse{}
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+	page := cfclient.Turnstile.Widgets.ListAutoPaging(ctx,
+		cfturnstile.WidgetListParams{
+			AccountID: cf.F(accountID),
+		})
+	if page.Err() != nil {
+		log.Errorf("error fetching turnstile widgets, AccountID:%s, err:%v", accountID, page.Err())
+		return nil
+	}
+
+	seenIDs := make(map[string]struct{})
+	for page.Next() {
+		if page.Err() != nil {
+			log.Errorf("error during paging turnstile widgets: %v", page.Err())
+			break
+		}
+		widget := page.Current()
+		if _, exists := seenIDs[widget.Sitekey]; exists {
+			log.Errorf("fetchTurnstileWidgets: duplicate sitekey detected (%s), breaking loop", widget.Sitekey)
+			break
+		}
+		seenIDs[widget.Sitekey] = struct{}{}
+		cfWidgets = append(cfWidgets, widget)
+	}
+
+	return cfWidgets
+}
+
+func fetchImagesStats(accountID string) (*cfimages.Stat, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+	return cfclient.Images.V1.Stats.Get(ctx, cfimages.V1StatGetParams{
+		AccountID: cf.F(accountID),
+	})
+}
+
+func fetchStreamStorageUsage(accountID string) (*cfstream.VideoStorageUsageResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+	return cfclient.Stream.Videos.StorageUsage(ctx, cfstream.VideoStorageUsageParams{
+		AccountID: cf.F(accountID),
+	})
+}
+
+func fetchCloudflareTunnels(account cfaccounts.Account) []cfzero_trust.TunnelListResponse {
+	// Non-nil when the list succeeds, so callers can tell an empty account from an error
+	cfTunnels := []cfzero_trust.TunnelListResponse{}
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+	page := cfclient.ZeroTrust.Tunnels.ListAutoPaging(ctx,
+		cfzero_trust.TunnelListParams{
+			AccountID: cf.F(account.ID),
+			PerPage:   cf.F(float64(apiPerPageLimit)),
+			IsDeleted: cf.F(false),
+		})
+	if page.Err() != nil {
+		log.Errorf("error fetching tunnels, err:%v", page.Err())
+		return nil
+	}
+
+	seenIDs := make(map[string]struct{})
+	for page.Next() {
+		if page.Err() != nil {
+			log.Errorf("error during paging tunnels: %v", page.Err())
+			break
+		}
+		tunnel := page.Current()
+		if _, exists := seenIDs[tunnel.ID]; exists {
+			log.Errorf("fetchCloudflareTunnels: duplicate tunnel ID detected (%s), breaking loop", tunnel.ID)
+			break
+		}
+		seenIDs[tunnel.ID] = struct{}{}
+		cfTunnels = append(cfTunnels, tunnel)
+	}
+
+	return cfTunnels
+}
+
+func fetchCloudflareTunnelConnectors(account cfaccounts.Account, tunnelID string) []cfzero_trust.Client {
+	// Non-nil when the list succeeds, so callers can tell an empty tunnel from an error
+	cfClients := []cfzero_trust.Client{}
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+	page := cfclient.ZeroTrust.Tunnels.Connections.GetAutoPaging(ctx,
+		tunnelID,
+		cfzero_trust.TunnelConnectionGetParams{
+			AccountID: cf.F(account.ID),
+		})
+	if page.Err() != nil {
+		log.Errorf("error fetching tunnel connections, err:%v", page.Err())
+		return nil
+	}
+
+	for page.Next() {
+		if page.Err() != nil {
+			log.Errorf("error during paging tunnel connections: %v", page.Err())
+			break
+		}
+		client := page.Current()
+		cfClients = append(cfClients, client)
+	}
+
+	return cfClients
+}
+
+func fetchAPIShieldOperations(zoneID string) []cfapi_gateway.OperationListResponse {
+	// Non-nil when the list succeeds, so callers can tell an empty zone from an error
+	cfOperations := []cfapi_gateway.OperationListResponse{}
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+	page := cfclient.APIGateway.Operations.ListAutoPaging(ctx,
+		cfapi_gateway.OperationListParams{
+			ZoneID: cf.F(zoneID),
+		})
+	if page.Err() != nil {
+		log.Errorf("error fetching API Shield operations, ZoneID:%s, err:%v", zoneID, page.Err())
+		return nil
+	}
+
+	seenIDs := make(map[string]struct{})
+	for page.Next() {
+		if page.Err() != nil {
+			log.Errorf("error during paging API Shield operations: %v", page.Err())
+			break
+		}
+		operation := page.Current()
+		if _, exists := seenIDs[operation.OperationID]; exists {
+			log.Errorf("fetchAPIShieldOperations: duplicate operation ID detected (%s), breaking loop", operation.OperationID)
+			break
+		}
+		seenIDs[operation.OperationID] = struct{}{}
+		cfOperations = append(cfOperations, operation)
+	}
+
+	return cfOperations
+}
+
+func fetchAPIShieldDiscoveredOperations(zoneID string) []cfapi_gateway.DiscoveryOperation {
+	// Non-nil when the list succeeds, so callers can tell an empty zone from an error
+	cfOperations := []cfapi_gateway.DiscoveryOperation{}
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+	page := cfclient.APIGateway.Discovery.Operations.ListAutoPaging(ctx,
+		cfapi_gateway.DiscoveryOperationListParams{
+			ZoneID: cf.F(zoneID),
+		})
+	if page.Err() != nil {
+		log.Errorf("error fetching API Shield discovered operations, ZoneID:%s, err:%v", zoneID, page.Err())
+		return nil
+	}
+
+	seenIDs := make(map[string]struct{})
+	for page.Next() {
+		if page.Err() != nil {
+			log.Errorf("error during paging API Shield discovered operations: %v", page.Err())
+			break
+		}
+		operation := page.Current()
+		if _, exists := seenIDs[operation.ID]; exists {
+			log.Errorf("fetchAPIShieldDiscoveredOperations: duplicate operation ID detected (%s), breaking loop", operation.ID)
+			break
+		}
+		seenIDs[operation.ID] = struct{}{}
+		cfOperations = append(cfOperations, operation)
+	}
+
+	return cfOperations
+}
+
+func fetchPageShieldScripts(zoneID string) []cfpage_shield.Script {
+	// Non-nil when the list succeeds, so callers can tell an empty zone from an error
+	cfScripts := []cfpage_shield.Script{}
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+	page := cfclient.PageShield.Scripts.ListAutoPaging(ctx,
+		cfpage_shield.ScriptListParams{
+			ZoneID: cf.F(zoneID),
+		})
+	if page.Err() != nil {
+		log.Errorf("error fetching Page Shield scripts, ZoneID:%s, err:%v", zoneID, page.Err())
+		return nil
+	}
+
+	seenIDs := make(map[string]struct{})
+	for page.Next() {
+		if page.Err() != nil {
+			log.Errorf("error during paging Page Shield scripts: %v", page.Err())
+			break
+		}
+		script := page.Current()
+		if _, exists := seenIDs[script.ID]; exists {
+			log.Errorf("fetchPageShieldScripts: duplicate script ID detected (%s), breaking loop", script.ID)
+			break
+		}
+		seenIDs[script.ID] = struct{}{}
+		cfScripts = append(cfScripts, script)
+	}
+
+	return cfScripts
+}
+
+func fetchPageShieldConnections(zoneID string) []cfpage_shield.Connection {
+	// Non-nil when the list succeeds, so callers can tell an empty zone from an error
+	cfConnections := []cfpage_shield.Connection{}
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+	page := cfclient.PageShield.Connections.ListAutoPaging(ctx,
+		cfpage_shield.ConnectionListParams{
+			ZoneID: cf.F(zoneID),
+		})
+	if page.Err() != nil {
+		log.Errorf("error fetching Page Shield connections, ZoneID:%s, err:%v", zoneID, page.Err())
+		return nil
+	}
+
+	seenIDs := make(map[string]struct{})
+	for page.Next() {
+		if page.Err() != nil {
+			log.Errorf("error during paging Page Shield connections: %v", page.Err())
+			break
+		}
+		connection := page.Current()
+		if _, exists := seenIDs[connection.ID]; exists {
+			log.Errorf("fetchPageShieldConnections: duplicate connection ID detected (%s), breaking loop", connection.ID)
+			break
+		}
+		seenIDs[connection.ID] = struct{}{}
+		cfConnections = append(cfConnections, connection)
+	}
+
+	return cfConnections
+}
+
+func fetchSpectrumCurrentConnections(zoneID string) []cfspectrum.AnalyticsAggregateCurrentGetResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+	current, err := cfclient.Spectrum.Analytics.Aggregates.Currents.Get(ctx,
+		cfspectrum.AnalyticsAggregateCurrentGetParams{
+			ZoneID: cf.F(zoneID),
+		})
+	if err != nil {
+		log.Errorf("error fetching spectrum current connections, ZoneID:%s, err:%v", zoneID, err)
+		return nil
+	}
+
+	return *current
+}
+
+func fetchWaitingRooms(zoneID string) []cfwaiting_rooms.WaitingRoom {
+	// Non-nil when the list succeeds, so callers can tell an empty zone from an error
+	cfWaitingRooms := []cfwaiting_rooms.WaitingRoom{}
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+	page := cfclient.WaitingRooms.ListAutoPaging(ctx,
+		cfwaiting_rooms.WaitingRoomListParams{
+			ZoneID: cf.F(zoneID),
+		})
+	if page.Err() != nil {
+		log.Errorf("error fetching waiting rooms, ZoneID:%s, err:%v", zoneID, page.Err())
+		return nil
+	}
+
+	seenIDs := make(map[string]struct{})
+	for page.Next() {
+		if page.Err() != nil {
+			log.Errorf("error during paging waiting rooms: %v", page.Err())
+			break
+		}
+		room := page.Current()
+		if _, exists := seenIDs[room.ID]; exists {
+			log.Errorf("fetchWaitingRooms: duplicate waiting room ID detected (%s), breaking loop", room.ID)
+			break
+		}
+		seenIDs[room.ID] = struct{}{}
+		cfWaitingRooms = append(cfWaitingRooms, room)
+	}
 
-	now, now1mAgo := GetTimeRange()
-	request.Var("limit", gqlQueryLimit)
-	request.Var("maxtime", now)
-	request.Var("mintime", now1mAgo)
-	request.Var("zoneIDs", zoneIDs)
+	return cfWaitingRooms
+}
 
-	gql.Mu.RLock()
-	defer gql.Mu.RUnlock()
+func fetchWaitingRoomStatus(zoneID string, waitingRoomID string) *cfwaiting_rooms.StatusGetResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+	status, err := cfclient.WaitingRooms.Statuses.Get(ctx, waitingRoomID, cfwaiting_rooms.StatusGetParams{
+		ZoneID: cf.F(zoneID),
+	})
+	if err != nil {
+		log.Errorf("error fetching waiting room status, ZoneID:%s, WaitingRoomID:%s, err:%v", zoneID, waitingRoomID, err)
+		return nil
+	}
 
+	return status
+}
+
+func fetchEmailRoutingRules(zoneID string) []cfemail_routing.EmailRoutingRule {
+	// Non-nil when the list succeeds, so callers can tell an empty zone from an error
+	cfRules := []cfemail_routing.EmailRoutingRule{}
 	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
 	defer cancel()
+	page := cfclient.EmailRouting.Rules.ListAutoPaging(ctx,
+		cfemail_routing.RuleListParams{
+			ZoneID: cf.F(zoneID),
+		})
+	if page.Err() != nil {
+		log.Errorf("error fetching email routing rules, ZoneID:%s, err:%v", zoneID, page.Err())
+		return nil
+	}
 
-	var resp cloudflareResponseLb
-	if err := gql.Client.Run(ctx, request, &resp); err != nil {
-		log.Errorf("error fetching load balancer totals, err:%v", err)
-		return nil, err
+	seenIDs := make(map[string]struct{})
+	for page.Next() {
+		if page.Err() != nil {
+			log.Errorf("error during paging email routing rules: %v", page.Err())
+			break
+		}
+		rule := page.Current()
+		if _, exists := seenIDs[rule.ID]; exists {
+			log.Errorf("fetchEmailRoutingRules: duplicate rule ID detected (%s), breaking loop", rule.ID)
+			break
+		}
+		seenIDs[rule.ID] = struct{}{}
+		cfRules = append(cfRules, rule)
 	}
-	return &resp, nil
+
+	return cfRules
 }
 
-func fetchLogpushAccount(accountID string) (*cloudflareResponseLogpushAccount, error) {
-	request := graphql.NewRequest(`query($accountID: String!, $limit: Int!, $mintime: Time!, $maxtime: Time!) {
-		viewer {
-		  accounts(filter: {accountTag : $accountID }) {
-			logpushHealthAdaptiveGroups(
-			  filter: {
-				datetime_geq: $mintime
-				datetime_lt: $maxtime
-				status_neq: 200
-			  }
-			  limit: $limit
-			) {
-			  count
-			  dimensions {
-				jobId
-				status
-				destinationType
-				datetime
-				final
-			  }
-			}
-		  }
+func fetchEmailRoutingAddresses(accountID string) []cfemail_routing.Address {
+	// Non-nil when the list succeeds, so callers can tell an empty account from an error
+	cfAddresses := []cfemail_routing.Address{}
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+	page := cfclient.EmailRouting.Addresses.ListAutoPaging(ctx,
+		cfemail_routing.AddressListParams{
+			AccountID: cf.F(accountID),
+		})
+	if page.Err() != nil {
+		log.Errorf("error fetching email routing addresses, AccountID:%s, err:%v", accountID, page.Err())
+		return nil
+	}
+
+	seenIDs := make(map[string]struct{})
+	for page.Next() {
+		if page.Err() != nil {
+			log.Errorf("error during paging email routing addresses: %v", page.Err())
+			break
 		}
-	  }`)
+		address := page.Current()
+		if _, exists := seenIDs[address.ID]; exists {
+			log.Errorf("fetchEmailRoutingAddresses: duplicate address ID detected (%s), breaking loop", address.ID)
+			break
+		}
+		seenIDs[address.ID] = struct{}{}
+		cfAddresses = append(cfAddresses, address)
+	}
 
-	now, now1mAgo := GetTimeRange()
-	request.Var("accountID", accountID)
-	request.Var("limit", gqlQueryLimit)
-	request.Var("maxtime", now)
-	request.Var("mintime", now1mAgo)
+	return cfAddresses
+}
 
-	gql.Mu.RLock()
-	defer gql.Mu.RUnlock()
+// fetchLogpushJobs lists the Logpush jobs of an account, or of a zone when
+// zoneID is set.
+func fetchLogpushJobs(accountID string, zoneID string) []cflogpush.LogpushJob {
+	var cfJobs []cflogpush.LogpushJob
+	params := cflogpush.JobListParams{}
+	if zoneID != "" {
+		params.ZoneID = cf.F(zoneID)
+	} else {
+		params.AccountID = cf.F(accountID)
+	}
 
-	var resp cloudflareResponseLogpushAccount
 	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
 	defer cancel()
-
-	if err := gql.Client.Run(ctx, request, &resp); err != nil {
-		log.Errorf("error fetching logpush account totals, err:%v", err)
-		return nil, err
+	page := cfclient.Logpush.Jobs.ListAutoPaging(ctx, params)
+	if page.Err() != nil {
+		log.Errorf("error fetching logpush jobs, AccountID:%s, ZoneID:%s, err:%v", accountID, zoneID, page.Err())
+		return nil
 	}
-	return &resp, nil
-}
 
-func fetchLogpushZone(zoneIDs []string) (*cloudflareResponseLogpushZone, error) {
-	request := graphql.NewRequest(`query($zoneIDs: String!, $limit: Int!, $mintime: Time!, $maxtime: Time!) {
-		viewer {
-			zones(filter: {zoneTag_in : $zoneIDs }) {
-			logpushHealthAdaptiveGroups(
-			  filter: {
-				datetime_geq: $mintime
-				datetime_lt: $maxtime
-				status_neq: 200
-			  }
-			  limit: $limit
-			) {
-			  count
-			  dimensions {
-				jobId
-				status
-				destinationType
-				datetime
-				final
-			  }
-			}
-		  }
+	seenIDs := make(map[int64]struct{})
+	for page.Next() {
+		if page.Err() != nil {
+			log.Errorf("error during paging logpush jobs: %v", page.Err())
+			break
 		}
-	  }`)
-
-	now, now1mAgo := GetTimeRange()
-	request.Var("zoneIDs", zoneIDs)
-	request.Var("limit", gqlQueryLimit)
-	request.Var("maxtime", now)
-	request.Var("mintime", now1mAgo)
+		job := page.Current()
+		if _, exists := seenIDs[job.ID]; exists {
+			log.Errorf("fetchLogpushJobs: duplicate job ID detected (%d), breaking loop", job.ID)
+			break
+		}
+		seenIDs[job.ID] = struct{}{}
+		cfJobs = append(cfJobs, job)
+	}
 
-	gql.Mu.RLock()
-	defer gql.Mu.RUnlock()
+	return cfJobs
+}
 
+func fetchWorkerScripts(accountID string) []cfworkers.Script {
+	// Non-nil when the list succeeds, so callers can tell an empty account from an error
+	cfScripts := []cfworkers.Script{}
 	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
 	defer cancel()
+	page := cfclient.Workers.Scripts.ListAutoPaging(ctx,
+		cfworkers.ScriptListParams{
+			AccountID: cf.F(accountID),
+		})
+	if page.Err() != nil {
+		log.Errorf("error fetching worker scripts, AccountID:%s, err:%v", accountID, page.Err())
+		return nil
+	}
 
-	var resp cloudflareResponseLogpushZone
-	if err := gql.Client.Run(ctx, request, &resp); err != nil {
-		log.Errorf("error fetching logpush zone totals, err:%v", err)
-		return nil, err
+	seenIDs := make(map[string]struct{})
+	for page.Next() {
+		if page.Err() != nil {
+			log.Errorf("error during paging worker scripts: %v", page.Err())
+			break
+		}
+		script := page.Current()
+		if _, exists := seenIDs[script.ID]; exists {
+			log.Errorf("fetchWorkerScripts: duplicate script ID detected (%s), breaking loop", script.ID)
+			break
+		}
+		seenIDs[script.ID] = struct{}{}
+		cfScripts = append(cfScripts, script)
 	}
 
-	return &resp, nil
+	return cfScripts
 }
 
-func fetchR2Account(accountID string) (*cloudflareResponseR2Account, error) {
-	request := graphql.NewRequest(`query($accountID: String!, $limit: Int!, $date: String!) {
-		viewer {
-		  accounts(filter: {accountTag : $accountID }) {
-			r2StorageAdaptiveGroups(
-			  filter: {
-				date: $date
-			  },
-			  limit: $limit
-			) {
-			  dimensions {
-          		bucketName
-			  }
-        	  max {
-				metadataSize
-          		payloadSize
-				objectCount
-			  }
-      		}
-			r2OperationsAdaptiveGroups(filter: { date: $date }, limit: $limit) {
-				dimensions {
-					actionType
-					bucketName
-				}
-				sum {
-					requests
-				}
-			}
-			}
-		  }
-	  }`)
+func fetchWorkerDeployments(accountID string, scriptName string) []cfworkers.ScriptDeploymentGetResponseDeployment {
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+	resp, err := cfclient.Workers.Scripts.Deployments.Get(ctx, scriptName,
+		cfworkers.ScriptDeploymentGetParams{
+			AccountID: cf.F(accountID),
+		})
+	if err != nil {
+		log.Errorf("error fetching worker deployments, script:%s, err:%v", scriptName, err)
+		return nil
+	}
+	return resp.Deployments
+}
 
-	now, _ := GetTimeRange()
-	request.Var("accountID", accountID)
-	request.Var("limit", gqlQueryLimit)
-	request.Var("date", now.Format("2006-01-02"))
+func fetchWorkerSchedules(accountID string, scriptName string) []cfworkers.Schedule {
+	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
+	defer cancel()
+	resp, err := cfclient.Workers.Scripts.Schedules.Get(ctx, scriptName,
+		cfworkers.ScriptScheduleGetParams{
+			AccountID: cf.F(accountID),
+		})
+	if err != nil {
+		log.Errorf("error fetching worker schedules, script:%s, err:%v", scriptName, err)
+		return nil
+	}
+	return resp.Schedules
+}
 
-	gql.Mu.RLock()
-	defer gql.Mu.RUnlock()
+var (
+	// Versions are immutable, so their compatibility date is only fetched once.
+	workerCompatibilityDateCache   = map[string]string{}
+	workerCompatibilityDateCacheMu sync.Mutex
+)
+
+func fetchWorkerCompatibilityDate(accountID string, scriptName string, versionID string) string {
+	workerCompatibilityDateCacheMu.Lock()
+	cached, exists := workerCompatibilityDateCache[versionID]
+	workerCompatibilityDateCacheMu.Unlock()
+	if exists {
+		return cached
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
 	defer cancel()
+	version, err := cfclient.Workers.Scripts.Versions.Get(ctx, scriptName, versionID,
+		cfworkers.ScriptVersionGetParams{
+			AccountID: cf.F(accountID),
+		})
+	if err != nil {
+		log.Errorf("error fetching worker version, script:%s, version:%s, err:%v", scriptName, versionID, err)
+		return ""
+	}
 
-	var resp cloudflareResponseR2Account
-	if err := gql.Client.Run(ctx, request, &resp); err != nil {
-		log.Errorf("error fetching R2 account: %v", err)
-		return nil, err
+	// resources.script_runtime.compatibility_date is untyped in the SDK
+	compatibilityDate := ""
+	if resources, ok := version.Resources.(map[string]interface{}); ok {
+		if runtime, ok := resources["script_runtime"].(map[string]interface{}); ok {
+			compatibilityDate, _ = runtime["compatibility_date"].(string)
+		}
 	}
-	return &resp, nil
+
+	workerCompatibilityDateCacheMu.Lock()
+	workerCompatibilityDateCache[versionID] = compatibilityDate
+	workerCompatibilityDateCacheMu.Unlock()
+	return compatibilityDate
 }
 
-func fetchCloudflareTunnels(account cfaccounts.Account) []cfzero_trust.TunnelListResponse {
-	var cfTunnels []cfzero_trust.TunnelListResponse
+func fetchWorkerDomains(accountID string) []cfworkers.Domain {
+	// Non-nil when the list succeeds, so callers can tell an empty account from an error
+	cfDomains := []cfworkers.Domain{}
 	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
 	defer cancel()
-	page := cfclient.ZeroTrust.Tunnels.ListAutoPaging(ctx,
-		cfzero_trust.TunnelListParams{
-			AccountID: cf.F(account.ID),
-			PerPage:   cf.F(float64(apiPerPageLimit)),
-			IsDeleted: cf.F(false),
+	page := cfclient.Workers.Domains.ListAutoPaging(ctx,
+		cfworkers.DomainListParams{
+			AccountID: cf.F(accountID),
 		})
 	if page.Err() != nil {
-		log.Errorf("error fetching tunnels, err:%v", page.Err())
+		log.Errorf("error fetching worker domains, AccountID:%s, err:%v", accountID, page.Err())
 		return nil
 	}
 
 	seenIDs := make(map[string]struct{})
 	for page.Next() {
 		if page.Err() != nil {
-			log.Errorf("error during paging tunnels: %v", page.Err())
+			log.Errorf("error during paging worker domains: %v", page.Err())
 			break
 		}
-		tunnel := page.Current()
-		if _, exists := seenIDs[tunnel.ID]; exists {
-			log.Errorf("fetchCloudflareTunnels: duplicate tunnel ID detected (%s), breaking loop", tunnel.ID)
+		domain := page.Current()
+		if _, exists := seenIDs[domain.ID]; exists {
+			log.Errorf("fetchWorkerDomains: duplicate domain ID detected (%s), breaking loop", domain.ID)
 			break
 		}
-		seenIDs[tunnel.ID] = struct{}{}
-		cfTunnels = append(cfTunnels, tunnel)
+		seenIDs[domain.ID] = struct{}{}
+		cfDomains = append(cfDomains, domain)
 	}
 
-	return cfTunnels
+	return cfDomains
 }
 
-func fetchCloudflareTunnelConnectors(account cfaccounts.Account, tunnelID string) []cfzero_trust.Client {
-	var cfClients []cfzero_trust.Client
+func fetchWorkerRoutes(zoneID string) []cfworkers.RouteListResponse {
+	// Non-nil when the list succeeds, so callers can tell an empty zone from an error
+	cfRoutes := []cfworkers.RouteListResponse{}
 	ctx, cancel := context.WithTimeout(context.Background(), cftimeout)
 	defer cancel()
-	page := cfclient.ZeroTrust.Tunnels.Connections.GetAutoPaging(ctx,
-		tunnelID,
-		cfzero_trust.TunnelConnectionGetParams{
-			AccountID: cf.F(account.ID),
+	page := cfclient.Workers.Routes.ListAutoPaging(ctx,
+		cfworkers.RouteListParams{
+			ZoneID: cf.F(zoneID),
 		})
 	if page.Err() != nil {
-		log.Errorf("error fetching tunnel connections, err:%v", page.Err())
+		log.Errorf("error fetching worker routes, ZoneID:%s, err:%v", zoneID, page.Err())
 		return nil
 	}
 
+	seenIDs := make(map[string]struct{})
 	for page.Next() {
 		if page.Err() != nil {
-			log.Errorf("error during paging tunnel connections: %v", page.Err())
+			log.Errorf("error during paging worker routes: %v", page.Err())
 			break
 		}
-		client := page.Current()
-		cfClients = append(cfClients, client)
+		route := page.Current()
+		if _, exists := seenIDs[route.ID]; exists {
+			log.Errorf("fetchWorkerRoutes: duplicate route ID detected (%s), breaking loop", route.ID)
+			break
+		}
+		seenIDs[route.ID] = struct{}{}
+		cfRoutes = append(cfRoutes, route)
 	}
 
-	return cfClients
+	return cfRoutes
 }
 
 func findZoneAccountName(zones []cfzones.Zone, ID string) (string, string) {
@@ -1000,3 +3081,16 @@ func filterNonFreePlanZones(zones []cfzones.Zone) (filteredZones []cfzones.Zone)
 	}
 	return
 }
+
+// getZonePlan returns the plan of a zone, e.g. free or enterprise.
+func getZonePlan(z cfzones.Zone) string {
+	extraFields, err := jsonStringToMap(z.JSON.ExtraFields["plan"].Raw())
+	if err != nil {
+		return ""
+	}
+	if legacyID, ok := extraFields["legacy_id"].(string); ok && legacyID != "" {
+		return legacyID
+	}
+	name, _ := extraFields["name"].(string)
+	return strings.ToLower(name)
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+// Usage dimensions that can be priced in the cost price table. Prices are in
+// USD per unit of the dimension, e.g. per request or per GB-month.
+const (
+	costWorkersRequests                      = "workers_requests"
+	costWorkersDurationGBs                   = "workers_duration_gb_s"
+	costR2StorageGBMonth                     = "r2_storage_gb_month"
+	costR2ClassAOperations                   = "r2_class_a_operations"
+	costR2ClassBOperations                   = "r2_class_b_operations"
+	costImagesStored                         = "images_stored"
+	costImagesDelivered                      = "images_delivered"
+	costImagesTransformations                = "images_transformations"
+	costStreamMinutesStored                  = "stream_minutes_stored"
+	costStreamMinutesDelivered               = "stream_minutes_delivered"
+	costArgoBytes                            = "argo_bytes"
+	costLoadBalancerRequests                 = "load_balancer_requests"
+	costInfrequentAccessSuffix               = "_infrequent_access"
+	costInfrequentAccessStorageClass         = "InfrequentAccess"
+	defaultCostBillingDay                    = 1
+	maxCostBillingDay                        = 28
+	bytesPerGB                       float64 = 1 << 30
+)
+
+type costKey struct {
+	dimension string
+	account   string
+	zone      string
+	script    string
+}
+
+type costUsage struct {
+	// accumulated is the sum of usage reported per scrape window
+	accumulated float64
+	// daily holds usage reported as a running total for the day, keyed by date
+	daily map[string]float64
+	// level is usage billed on the current amount, such as storage per month
+	level float64
+}
+
+func (u *costUsage) total() float64 {
+	total := u.accumulated + u.level
+	for _, v := range u.daily {
+		total += v
+	}
+	return total
+}
+
+type costEstimator struct {
+	mu          sync.Mutex
+	prices      map[string]float64
+	billingDay  int
+	periodStart time.Time
+	// Usage is only kept in memory, a restart starts the month from zero
+	usage map[costKey]*costUsage
+}
+
+var costs *costEstimator
+
+// loadCostPriceTable reads the price table from the file configured with
+// cost_price_table. Cost estimation stays disabled when no file is set.
+func loadCostPriceTable() error {
+	path := viper.GetString("cost_price_table")
+	if path == "" {
+		return nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read cost price table %s: %w", path, err)
+	}
+
+	prices := make(map[string]float64)
+	if err := v.UnmarshalKey("prices", &prices); err != nil {
+		return fmt.Errorf("failed to parse cost price table %s: %w", path, err)
+	}
+
+	billingDay := viper.GetInt("cost_billing_day")
+	if billingDay < 1 || billingDay > maxCostBillingDay {
+		return fmt.Errorf("cost_billing_day must be between 1 and %d, got %d", maxCostBillingDay, billingDay)
+	}
+
+	costs = &costEstimator{
+		prices:     prices,
+		billingDay: billingDay,
+		usage:      make(map[costKey]*costUsage),
+	}
+	log.Infof("Cost estimation enabled with %d prices, billing month starts on day %d", len(prices), billingDay)
+	return nil
+}
+
+// billingPeriodStart returns the start of the billing month containing t.
+func billingPeriodStart(t time.Time, billingDay int) time.Time {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), billingDay, 0, 0, 0, 0, time.UTC)
+	if t.Before(start) {
+		start = start.AddDate(0, -1, 0)
+	}
+	return start
+}
+
+// price returns the price of a dimension, falling back to the Standard
+// storage class price when no Infrequent Access price is configured.
+func (c *costEstimator) price(dimension string) (float64, bool) {
+	if p, exists := c.prices[dimension]; exists {
+		return p, true
+	}
+	if base, found := strings.CutSuffix(dimension, costInfrequentAccessSuffix); found {
+		p, exists := c.prices[base]
+		return p, exists
+	}
+	return 0, false
+}
+
+func (c *costEstimator) record(key costKey, update func(*costUsage)) {
+	price, exists := c.price(key.dimension)
+	if !exists {
+		return
+	}
+
+	// Collectors label accounts either by name or by normalised name, use the
+	// normalised one so an account is never split across two series
+	key.account = strings.ToLower(strings.ReplaceAll(key.account, " ", "-"))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now, _ := GetTimeRange()
+	start := billingPeriodStart(now, c.billingDay)
+	if !start.Equal(c.periodStart) {
+		// A new billing month started, drop the usage of the previous one
+		c.periodStart = start
+		c.usage = make(map[costKey]*costUsage)
+		estimatedCost.Reset()
+	}
+
+	u, exists := c.usage[key]
+	if !exists {
+		u = &costUsage{daily: make(map[string]float64)}
+		c.usage[key] = u
+	}
+	update(u)
+
+	estimatedCost.With(prometheus.Labels{
+		"account":   key.account,
+		"zone":      key.zone,
+		"script":    key.script,
+		"dimension": key.dimension,
+	}).Set(u.total() * price)
+}
+
+// addCostUsage adds usage observed during the last scrape window.
+func addCostUsage(dimension, account, zone, script string, quantity float64) {
+	if costs == nil {
+		return
+	}
+	costs.record(costKey{dimension, account, zone, script}, func(u *costUsage) {
+		u.accumulated += quantity
+	})
+}
+
+// setCostDailyUsage records usage reported as a running total for the day.
+func setCostDailyUsage(dimension, account, zone, script, date string, quantity float64) {
+	if costs == nil {
+		return
+	}
+	costs.record(costKey{dimension, account, zone, script}, func(u *costUsage) {
+		u.daily[date] = quantity
+	})
+}
+
+// setCostLevelUsage records usage billed on the current amount for the month.
+func setCostLevelUsage(dimension, account, zone, script string, quantity float64) {
+	if costs == nil {
+		return
+	}
+	costs.record(costKey{dimension, account, zone, script}, func(u *costUsage) {
+		u.level = quantity
+	})
+}
+
+// getR2CostDimension returns the priced dimension for an R2 storage class.
+func getR2CostDimension(dimension, storageClass string) string {
+	if storageClass == costInfrequentAccessStorageClass {
+		return dimension + costInfrequentAccessSuffix
+	}
+	return dimension
+}
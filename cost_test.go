@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBillingPeriodStart(t *testing.T) {
+	tests := []struct {
+		name       string
+		t          time.Time
+		billingDay int
+		want       time.Time
+	}{
+		{
+			name:       "first of the month",
+			t:          time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC),
+			billingDay: 1,
+			want:       time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "on the billing day",
+			t:          time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+			billingDay: 15,
+			want:       time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "before the billing day",
+			t:          time.Date(2026, 3, 14, 23, 59, 59, 0, time.UTC),
+			billingDay: 15,
+			want:       time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "across the year",
+			t:          time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+			billingDay: 10,
+			want:       time.Date(2025, 12, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "other time zones use UTC",
+			t:          time.Date(2026, 3, 15, 1, 0, 0, 0, time.FixedZone("UTC+5", 5*60*60)),
+			billingDay: 15,
+			want:       time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := billingPeriodStart(tt.t, tt.billingDay); !got.Equal(tt.want) {
+				t.Errorf("billingPeriodStart(%v, %d) = %v, want %v", tt.t, tt.billingDay, got, tt.want)
+			}
+		})
+	}
+}
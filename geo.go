@@ -0,0 +1,115 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/biter777/countries"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Country codes Cloudflare uses for requests without a country.
+const (
+	countryTor     = "T1"
+	countryUnknown = "XX"
+	regionTor      = "Tor"
+	regionUnknown  = "Unknown"
+)
+
+// m49Regions maps the continents and subregions of the UN M49 geoscheme to
+// their countries, with the intermediate regions for Sub-Saharan Africa and
+// Latin America. Antarctica has no M49 region and is its own continent.
+var m49Regions = map[string]map[string][]string{
+	"Africa": {
+		"Northern Africa": {"DZ", "EG", "EH", "LY", "MA", "SD", "TN"},
+		"Eastern Africa":  {"BI", "DJ", "ER", "ET", "IO", "KE", "KM", "MG", "MU", "MW", "MZ", "RE", "RW", "SC", "SO", "SS", "TF", "TZ", "UG", "YT", "ZM", "ZW"},
+		"Middle Africa":   {"AO", "CD", "CF", "CG", "CM", "GA", "GQ", "ST", "TD"},
+		"Southern Africa": {"BW", "LS", "NA", "SZ", "ZA"},
+		"Western Africa":  {"BF", "BJ", "CI", "CV", "GH", "GM", "GN", "GW", "LR", "ML", "MR", "NE", "NG", "SH", "SL", "SN", "TG"},
+	},
+	"Americas": {
+		"Caribbean":        {"AG", "AI", "AW", "BB", "BL", "BQ", "BS", "CU", "CW", "DM", "DO", "GD", "GP", "HT", "JM", "KN", "KY", "LC", "MF", "MQ", "MS", "PR", "SX", "TC", "TT", "VC", "VG", "VI"},
+		"Central America":  {"BZ", "CR", "GT", "HN", "MX", "NI", "PA", "SV"},
+		"South America":    {"AR", "BO", "BR", "BV", "CL", "CO", "EC", "FK", "GF", "GS", "GY", "PE", "PY", "SR", "UY", "VE"},
+		"Northern America": {"BM", "CA", "GL", "PM", "US"},
+	},
+	"Asia": {
+		"Central Asia":       {"KG", "KZ", "TJ", "TM", "UZ"},
+		"Eastern Asia":       {"CN", "HK", "JP", "KP", "KR", "MN", "MO", "TW"},
+		"South-eastern Asia": {"BN", "ID", "KH", "LA", "MM", "MY", "PH", "SG", "TH", "TL", "VN"},
+		"Southern Asia":      {"AF", "BD", "BT", "IN", "IR", "LK", "MV", "NP", "PK"},
+		"Western Asia":       {"AE", "AM", "AZ", "BH", "CY", "GE", "IL", "IQ", "JO", "KW", "LB", "OM", "PS", "QA", "SA", "SY", "TR", "YE"},
+	},
+	"Europe": {
+		"Eastern Europe":  {"BG", "BY", "CZ", "HU", "MD", "PL", "RO", "RU", "SK", "UA"},
+		"Northern Europe": {"AX", "DK", "EE", "FI", "FO", "GB", "GG", "IE", "IM", "IS", "JE", "LT", "LV", "NO", "SE", "SJ"},
+		"Southern Europe": {"AD", "AL", "BA", "ES", "GI", "GR", "HR", "IT", "ME", "MK", "MT", "PT", "RS", "SI", "SM", "VA", "XK"},
+		"Western Europe":  {"AT", "BE", "CH", "DE", "FR", "LI", "LU", "MC", "NL"},
+	},
+	"Oceania": {
+		"Australia and New Zealand": {"AU", "CC", "CX", "HM", "NF", "NZ"},
+		"Melanesia":                 {"FJ", "NC", "PG", "SB", "VU"},
+		"Micronesia":                {"FM", "GU", "KI", "MH", "MP", "NR", "PW", "UM"},
+		"Polynesia":                 {"AS", "CK", "NU", "PF", "PN", "TK", "TO", "TV", "WF", "WS"},
+	},
+	"Antarctica": {
+		"Antarctica": {"AQ"},
+	},
+}
+
+// regionByCountry maps a country code to its continent and subregion.
+var regionByCountry = func() map[string]countryLabels {
+	m := make(map[string]countryLabels)
+	for continent, subregions := range m49Regions {
+		for subregion, codes := range subregions {
+			for _, code := range codes {
+				m[code] = countryLabels{code, continent, subregion}
+			}
+		}
+	}
+	return m
+}()
+
+// countryLabels are the normalised labels of a country as reported by the
+// GraphQL API, either an ISO 3166 alpha-2 code or a country name.
+type countryLabels struct {
+	country   string
+	continent string
+	subregion string
+}
+
+func normalizeCountry(raw string) countryLabels {
+	code := strings.ToUpper(strings.TrimSpace(raw))
+	switch code {
+	case countryTor:
+		return countryLabels{countryTor, regionTor, regionTor}
+	case "", countryUnknown:
+		return countryLabels{countryUnknown, regionUnknown, regionUnknown}
+	}
+
+	// Codes unknown to the library, such as XK for Kosovo, are looked up as is
+	c := countries.ByName(raw)
+	if c != countries.Unknown {
+		code = c.Alpha2()
+	}
+	if labels, exists := regionByCountry[code]; exists {
+		return labels
+	}
+	if c != countries.Unknown {
+		return countryLabels{code, regionUnknown, regionUnknown}
+	}
+	return countryLabels{countryUnknown, regionUnknown, regionUnknown}
+}
+
+// legacyRegion returns the deprecated region label of the country metrics,
+// the region of the country as known to the countries library.
+func legacyRegion(raw string) string {
+	return countries.ByName(raw).Info().Region.Info().Name
+}
+
+// apply sets the country, continent and subregion labels.
+func (c countryLabels) apply(labels prometheus.Labels) prometheus.Labels {
+	labels["country"] = c.country
+	labels["continent"] = c.continent
+	labels["subregion"] = c.subregion
+	return labels
+}
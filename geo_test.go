@@ -0,0 +1,52 @@
+package main
+
+import "testing"
+
+func TestNormalizeCountry(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want countryLabels
+	}{
+		{"DE", countryLabels{"DE", "Europe", "Western Europe"}},
+		{"de", countryLabels{"DE", "Europe", "Western Europe"}},
+		{" US ", countryLabels{"US", "Americas", "Northern America"}},
+		{"Germany", countryLabels{"DE", "Europe", "Western Europe"}},
+		{"Japan", countryLabels{"JP", "Asia", "Eastern Asia"}},
+		{"BR", countryLabels{"BR", "Americas", "South America"}},
+		{"NZ", countryLabels{"NZ", "Oceania", "Australia and New Zealand"}},
+		{"AQ", countryLabels{"AQ", "Antarctica", "Antarctica"}},
+		{"XK", countryLabels{"XK", "Europe", "Southern Europe"}},
+		{"T1", countryLabels{countryTor, regionTor, regionTor}},
+		{"t1", countryLabels{countryTor, regionTor, regionTor}},
+		{"XX", countryLabels{countryUnknown, regionUnknown, regionUnknown}},
+		{"", countryLabels{countryUnknown, regionUnknown, regionUnknown}},
+		{"Atlantis", countryLabels{countryUnknown, regionUnknown, regionUnknown}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			if got := normalizeCountry(tt.raw); got != tt.want {
+				t.Errorf("normalizeCountry(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestM49RegionsAssignEveryCountryOnce(t *testing.T) {
+	seen := map[string]string{}
+	for _, subregions := range m49Regions {
+		for subregion, codes := range subregions {
+			for _, code := range codes {
+				if previous, exists := seen[code]; exists {
+					t.Errorf("country %s is in both %s and %s", code, previous, subregion)
+				}
+				seen[code] = subregion
+			}
+		}
+	}
+	for code := range seen {
+		if got := normalizeCountry(code); got.country != code {
+			t.Errorf("normalizeCountry(%q) returned country %s", code, got.country)
+		}
+	}
+}
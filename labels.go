@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	cfaccounts "github.com/cloudflare/cloudflare-go/v4/accounts"
+	cfzones "github.com/cloudflare/cloudflare-go/v4/zones"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/model"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Labels that can be enabled with enrich_labels.
+const (
+	enrichLabelZoneID    = "zone_id"
+	enrichLabelAccountID = "account_id"
+	enrichLabelPlan      = "plan"
+)
+
+type zoneMetadata struct {
+	id        string
+	accountID string
+	plan      string
+}
+
+var (
+	// Zone and account metadata from the last scrape, keyed by the names
+	// used in the zone and account labels.
+	zoneMetadataByName = map[string]zoneMetadata{}
+	accountIDByName    = map[string]string{}
+	labelMetadataMu    sync.RWMutex
+)
+
+// updateLabelMetadata records the IDs of the scraped accounts and the IDs
+// and plans of their zones, so that series can be enriched with them when
+// gathered. Accounts without zones, e.g. Workers or R2 only accounts, are
+// included.
+func updateLabelMetadata(accounts []cfaccounts.Account, zones []cfzones.Zone) {
+	byName := make(map[string]zoneMetadata, len(zones))
+	for _, z := range zones {
+		byName[z.Name] = zoneMetadata{
+			id:        z.ID,
+			accountID: z.Account.ID,
+			plan:      getZonePlan(z),
+		}
+	}
+
+	accountIDs := make(map[string]string, 2*len(accounts))
+	for _, a := range accounts {
+		// Zone collectors lower-case the account name, account collectors do not
+		accountIDs[a.Name] = a.ID
+		accountIDs[strings.ToLower(strings.ReplaceAll(a.Name, " ", "-"))] = a.ID
+	}
+
+	labelMetadataMu.Lock()
+	defer labelMetadataMu.Unlock()
+	zoneMetadataByName = byName
+	accountIDByName = accountIDs
+}
+
+// enrichingGatherer adds zone and account metadata labels, and the labels of
+// the zone labels file, to every zone and account scoped series.
+type enrichingGatherer struct {
+	gatherer prometheus.Gatherer
+	enrich   map[string]bool
+	// zoneLabels holds the labels of the zone labels file by zone name or ID
+	zoneLabels     map[string]map[string]string
+	zoneLabelNames []string
+}
+
+func newEnrichingGatherer(gatherer prometheus.Gatherer) (*enrichingGatherer, error) {
+	g := &enrichingGatherer{
+		gatherer: gatherer,
+		enrich:   map[string]bool{},
+	}
+
+	if len(viper.GetString("enrich_labels")) > 0 {
+		for _, label := range strings.Split(viper.GetString("enrich_labels"), ",") {
+			switch label {
+			case enrichLabelZoneID, enrichLabelAccountID, enrichLabelPlan:
+				g.enrich[label] = true
+			default:
+				return nil, fmt.Errorf("unknown enrich label %s, expected %s, %s or %s", label, enrichLabelZoneID, enrichLabelAccountID, enrichLabelPlan)
+			}
+		}
+	}
+
+	if path := viper.GetString("zone_labels_file"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read zone labels file %s: %w", path, err)
+		}
+		if g.zoneLabels, err = parseZoneLabels(data); err != nil {
+			return nil, fmt.Errorf("failed to parse zone labels file %s: %w", path, err)
+		}
+
+		names := map[string]struct{}{}
+		for _, labels := range g.zoneLabels {
+			for name := range labels {
+				// Invalid names break the exposition format and fail the whole scrape
+				if !model.LabelName(name).IsValid() || strings.HasPrefix(name, model.ReservedLabelPrefix) {
+					return nil, fmt.Errorf("invalid label name %q in zone labels file %s", name, path)
+				}
+				names[name] = struct{}{}
+			}
+		}
+		for name := range names {
+			g.zoneLabelNames = append(g.zoneLabelNames, name)
+		}
+		slices.Sort(g.zoneLabelNames)
+	}
+
+	return g, nil
+}
+
+// parseZoneLabels parses a zone labels file. JSON is valid YAML, so both
+// formats are read by the YAML decoder, which unlike viper keeps the case of
+// label names.
+func parseZoneLabels(data []byte) (map[string]map[string]string, error) {
+	var file struct {
+		Zones map[string]map[string]string `yaml:"zones"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	return file.Zones, nil
+}
+
+func (g *enrichingGatherer) Gather() ([]*dto.MetricFamily, error) {
+	mfs, err := g.gatherer.Gather()
+	if len(g.enrich) == 0 && len(g.zoneLabelNames) == 0 {
+		return mfs, err
+	}
+
+	labelMetadataMu.RLock()
+	defer labelMetadataMu.RUnlock()
+
+	for _, mf := range mfs {
+		for _, m := range mf.GetMetric() {
+			g.enrichMetric(m)
+		}
+	}
+	return mfs, err
+}
+
+func (g *enrichingGatherer) enrichMetric(m *dto.Metric) {
+	var zone, account string
+	var hasZone, hasAccount bool
+	existing := make(map[string]struct{}, len(m.GetLabel()))
+	for _, lp := range m.GetLabel() {
+		existing[lp.GetName()] = struct{}{}
+		switch lp.GetName() {
+		case "zone":
+			zone, hasZone = lp.GetValue(), true
+		case "account":
+			account, hasAccount = lp.GetValue(), true
+		}
+	}
+	if !hasZone && !hasAccount {
+		return
+	}
+
+	add := func(name, value string) {
+		if _, exists := existing[name]; exists {
+			return
+		}
+		m.Label = append(m.Label, &dto.LabelPair{Name: &name, Value: &value})
+	}
+
+	metadata := zoneMetadataByName[zone]
+	if hasZone {
+		if g.enrich[enrichLabelZoneID] {
+			add(enrichLabelZoneID, metadata.id)
+		}
+		if g.enrich[enrichLabelPlan] {
+			add(enrichLabelPlan, metadata.plan)
+		}
+
+		labels, exists := g.zoneLabels[zone]
+		if !exists {
+			labels = g.zoneLabels[metadata.id]
+		}
+		for _, name := range g.zoneLabelNames {
+			add(name, labels[name])
+		}
+	}
+	if g.enrich[enrichLabelAccountID] {
+		accountID := metadata.accountID
+		if accountID == "" {
+			accountID = accountIDByName[account]
+		}
+		add(enrichLabelAccountID, accountID)
+	}
+
+	slices.SortFunc(m.Label, func(a, b *dto.LabelPair) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseZoneLabels(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want map[string]map[string]string
+	}{
+		{
+			name: "yaml keeps the case of label names",
+			data: "zones:\n  example.com:\n    Team: web\n    cost_center: \"42\"\n",
+			want: map[string]map[string]string{"example.com": {"Team": "web", "cost_center": "42"}},
+		},
+		{
+			name: "json",
+			data: `{"zones": {"023e105f4ecef8ad9ca31a8372d0c353": {"Team": "api"}}}`,
+			want: map[string]map[string]string{"023e105f4ecef8ad9ca31a8372d0c353": {"Team": "api"}},
+		},
+		{
+			name: "no zones",
+			data: "other: true\n",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseZoneLabels([]byte(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseZoneLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -9,11 +9,13 @@ import (
 	"time"
 
 	"github.com/nelkinda/health-go"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	cf "github.com/cloudflare/cloudflare-go/v4"
+	cfaccounts "github.com/cloudflare/cloudflare-go/v4/accounts"
 	cfoption "github.com/cloudflare/cloudflare-go/v4/option"
 	cfzones "github.com/cloudflare/cloudflare-go/v4/zones"
 	"github.com/sirupsen/logrus"
@@ -103,28 +105,68 @@ func filterExcludedZones(all []cfzones.Zone, exclude []string) []cfzones.Zone {
 	return filtered
 }
 
+// Collectors run every scrape, by the name used in metricCatalogGroups.
+var (
+	accountCollectors = []struct {
+		name  string
+		fetch func(cfaccounts.Account, *sync.WaitGroup)
+	}{
+		{"fetchWorkerAnalytics", fetchWorkerAnalytics},
+		{"fetchWorkerInventoryForAccount", fetchWorkerInventoryForAccount},
+		{"fetchAIAnalytics", fetchAIAnalytics},
+		{"fetchLogpushAnalyticsForAccount", fetchLogpushAnalyticsForAccount},
+		{"fetchR2StorageForAccount", fetchR2StorageForAccount},
+		{"fetchImagesUsageForAccount", fetchImagesUsageForAccount},
+		{"fetchStreamUsageForAccount", fetchStreamUsageForAccount},
+		{"fetchTurnstileAnalyticsForAccount", fetchTurnstileAnalyticsForAccount},
+		{"fetchLoadblancerPoolsHealth", fetchLoadblancerPoolsHealth},
+		{"fetchZeroTrustAnalyticsForAccount", fetchZeroTrustAnalyticsForAccount},
+		{"fetchMagicTransitAnalyticsForAccount", fetchMagicTransitAnalyticsForAccount},
+		{"fetchDDoSAnalyticsForAccount", fetchDDoSAnalyticsForAccount},
+		{"fetchRUMAnalyticsForAccount", fetchRUMAnalyticsForAccount},
+		{"fetchEmailRoutingAddressesForAccount", fetchEmailRoutingAddressesForAccount},
+	}
+	zoneCollectors = []struct {
+		name  string
+		fetch func([]cfzones.Zone, *sync.WaitGroup)
+	}{
+		{"fetchZoneAnalytics", fetchZoneAnalytics},
+		{"fetchZoneColocationAnalytics", fetchZoneColocationAnalytics},
+		{"fetchLoadBalancerAnalytics", fetchLoadBalancerAnalytics},
+		{"fetchLogpushAnalyticsForZone", fetchLogpushAnalyticsForZone},
+		{"fetchBotManagementAnalytics", fetchBotManagementAnalytics},
+		{"fetchSecurityAnalytics", fetchSecurityAnalytics},
+		{"fetchAPIShieldAnalytics", fetchAPIShieldAnalytics},
+		{"fetchPageShieldAnalytics", fetchPageShieldAnalytics},
+		{"fetchSpectrumAnalytics", fetchSpectrumAnalytics},
+		{"fetchDDoSAnalytics", fetchDDoSAnalytics},
+		{"fetchWaitingRoomAnalytics", fetchWaitingRoomAnalytics},
+		{"fetchEmailRoutingAnalytics", fetchEmailRoutingAnalytics},
+		{"fetchArgoAnalytics", fetchArgoAnalytics},
+		{"fetchWorkerRoutesAnalytics", fetchWorkerRoutesAnalytics},
+		{"fetchTopNAnalytics", fetchTopNAnalytics},
+	}
+
+	// Collectors without enabled metrics are skipped, set at startup
+	collectorsEnabled map[string]bool
+)
+
 func fetchMetrics() {
 	var wg sync.WaitGroup
 	accounts := fetchAccounts()
 
 	for _, a := range accounts {
-		wg.Add(1)
-		go fetchWorkerAnalytics(a, &wg)
-
-		wg.Add(1)
-		go fetchLogpushAnalyticsForAccount(a, &wg)
-
-		wg.Add(1)
-		go fetchR2StorageForAccount(a, &wg)
-
-		wg.Add(1)
-		go fetchLoadblancerPoolsHealth(a, &wg)
-
-		wg.Add(1)
-		go fetchZeroTrustAnalyticsForAccount(a, &wg)
+		for _, c := range accountCollectors {
+			if !collectorsEnabled[c.name] {
+				continue
+			}
+			wg.Add(1)
+			go c.fetch(a, &wg)
+		}
 	}
 
 	zones := fetchZones(accounts)
+	updateLabelMetadata(accounts, zones)
 	tzones := getTargetZones()
 	fzones := filterZones(zones, tzones)
 	ezones := getExcludedZones()
@@ -132,37 +174,22 @@ func fetchMetrics() {
 	if !viper.GetBool("free_tier") {
 		filteredZones = filterNonFreePlanZones(filteredZones)
 	}
+	prunePageShieldScriptStates(filteredZones)
+	pruneSecurityRulesetCache(filteredZones)
 
+	// Zones are queried in batches of cfgraphqlreqlimit
 	zoneCount := len(filteredZones)
-	if zoneCount > 0 && zoneCount <= cfgraphqlreqlimit {
-		wg.Add(1)
-		go fetchZoneAnalytics(filteredZones, &wg)
-
-		wg.Add(1)
-		go fetchZoneColocationAnalytics(filteredZones, &wg)
-
-		wg.Add(1)
-		go fetchLoadBalancerAnalytics(filteredZones, &wg)
-
-		wg.Add(1)
-		go fetchLogpushAnalyticsForZone(filteredZones, &wg)
-	} else if zoneCount > cfgraphqlreqlimit {
-		for s := 0; s < zoneCount; s += cfgraphqlreqlimit {
-			e := s + cfgraphqlreqlimit
-			if e > zoneCount {
-				e = zoneCount
+	for s := 0; s < zoneCount; s += cfgraphqlreqlimit {
+		e := s + cfgraphqlreqlimit
+		if e > zoneCount {
+			e = zoneCount
+		}
+		for _, c := range zoneCollectors {
+			if !collectorsEnabled[c.name] {
+				continue
 			}
 			wg.Add(1)
-			go fetchZoneAnalytics(filteredZones[s:e], &wg)
-
-			wg.Add(1)
-			go fetchZoneColocationAnalytics(filteredZones[s:e], &wg)
-
-			wg.Add(1)
-			go fetchLoadBalancerAnalytics(filteredZones[s:e], &wg)
-
-			wg.Add(1)
-			go fetchLogpushAnalyticsForZone(filteredZones[s:e], &wg)
+			go c.fetch(filteredZones[s:e], &wg)
 		}
 	}
 
@@ -185,12 +212,29 @@ func runExporter() {
 	if len(viper.GetString("metrics_denylist")) > 0 {
 		metricsDenylist = strings.Split(viper.GetString("metrics_denylist"), ",")
 	}
-	metricsSet, err := buildFilteredMetricsSet(metricsDenylist)
+	metricsAllowlist := []string{}
+	if len(viper.GetString("metrics_allowlist")) > 0 {
+		metricsAllowlist = strings.Split(viper.GetString("metrics_allowlist"), ",")
+	}
+	metricsSet, err := buildFilteredMetricsSet(metricsAllowlist, metricsDenylist)
 	if err != nil {
 		log.Fatalf("Error building metrics set: %v", err)
 	}
 	log.Debugf("Metrics set: %v", metricsSet)
+	switch viper.GetString("worker_latency_type") {
+	case workerLatencyTypeGauge, workerLatencyTypeSummary:
+	default:
+		log.Fatalf("Invalid worker_latency_type %q, expected %s or %s", viper.GetString("worker_latency_type"), workerLatencyTypeGauge, workerLatencyTypeSummary)
+	}
+	if viper.GetInt("stream_top_videos") < 1 {
+		log.Fatalf("Invalid stream_top_videos %d, must be at least 1", viper.GetInt("stream_top_videos"))
+	}
 	mustRegisterMetrics(metricsSet)
+	collectorsEnabled = enabledCollectors(metricsSet)
+
+	if err := loadCostPriceTable(); err != nil {
+		log.Fatalf("Error loading cost price table: %v", err)
+	}
 
 	scrapeInterval := time.Duration(viper.GetInt("scrape_interval")) * time.Second
 	log.Info("Scrape interval set to ", scrapeInterval)
@@ -207,7 +251,18 @@ func runExporter() {
 		cfgMetricsPath = "/" + viper.GetString("metrics_path")
 	}
 
-	http.Handle(cfgMetricsPath, promhttp.Handler())
+	limitedGatherer, err := newCardinalityGatherer(prometheus.DefaultGatherer, metricsSet)
+	if err != nil {
+		log.Fatalf("Error configuring cardinality limits: %v", err)
+	}
+	gatherer, err := newEnrichingGatherer(limitedGatherer)
+	if err != nil {
+		log.Fatalf("Error configuring label enrichment: %v", err)
+	}
+	http.Handle(cfgMetricsPath, promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer, promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}),
+	))
+	http.Handle(metricCatalogPath, metricCatalogHandler(metricsSet))
 	h := health.New(health.Health{})
 	http.HandleFunc("/health", h.Handler)
 
@@ -275,10 +330,78 @@ func main() {
 	viper.BindEnv("cf_timeout")
 	viper.SetDefault("cf_timeout", 10*time.Second)
 
-	flags.String("metrics_denylist", "", "metrics to not expose, comma delimited list")
+	flags.String("metrics_denylist", "", "metrics to not expose, comma delimited list of metric names, globs or regular expressions")
 	viper.BindEnv("metrics_denylist")
 	viper.SetDefault("metrics_denylist", "")
 
+	flags.String("metrics_allowlist", "", "metrics to export, comma delimited list of metric names, globs or regular expressions, all metrics are exported if not set")
+	viper.BindEnv("metrics_allowlist")
+	viper.SetDefault("metrics_allowlist", "")
+
+	flags.String("metrics_drop_labels", "", "labels to aggregate away before export, comma delimited list of metric=label|label")
+	viper.BindEnv("metrics_drop_labels")
+	viper.SetDefault("metrics_drop_labels", "")
+
+	flags.String("metrics_series_limit", "", "maximum number of series per metric, comma delimited list of metric=limit, * sets the limit of all other counters")
+	viper.BindEnv("metrics_series_limit")
+	viper.SetDefault("metrics_series_limit", "")
+
+	flags.Bool("sampling_correction", false, "scale counts of sampled adaptive datasets by their sample interval to estimate totals")
+	viper.BindEnv("sampling_correction")
+	viper.SetDefault("sampling_correction", false)
+
+	flags.String("worker_latency_type", workerLatencyTypeGauge, "type of the worker cpu time, duration and wall time metrics, gauge (quantile gauges) or summary, defaults to gauge")
+	viper.BindEnv("worker_latency_type")
+	viper.SetDefault("worker_latency_type", workerLatencyTypeGauge)
+
+	flags.Int("inventory_interval", 900, "interval in seconds between refreshes of the worker script inventory and waiting room status, defaults to 900")
+	viper.BindEnv("inventory_interval")
+	viper.SetDefault("inventory_interval", 900)
+
+	flags.String("cost_price_table", "", "path to a price table file (yaml or json) enabling cloudflare_estimated_cost_usd, cost estimation is disabled if not set")
+	viper.BindEnv("cost_price_table")
+	viper.SetDefault("cost_price_table", "")
+
+	flags.Int("cost_billing_day", defaultCostBillingDay, "day of the month (1-28) on which the billing month starts, defaults to 1")
+	viper.BindEnv("cost_billing_day")
+	viper.SetDefault("cost_billing_day", defaultCostBillingDay)
+
+	flags.String("enrich_labels", "", "metadata labels to add to zone and account scoped metrics, comma delimited list of zone_id, account_id and plan")
+	viper.BindEnv("enrich_labels")
+	viper.SetDefault("enrich_labels", "")
+
+	flags.String("zone_labels_file", "", "path to a file (yaml or json) mapping zone names or IDs to static labels added to zone scoped metrics")
+	viper.BindEnv("zone_labels_file")
+	viper.SetDefault("zone_labels_file", "")
+
+	flags.Int("top_paths", 10, "number of top request paths by requests to export per zone, 0 disables, defaults to 10")
+	viper.BindEnv("top_paths")
+	viper.SetDefault("top_paths", 10)
+
+	flags.Int("top_hosts", 10, "number of top hosts by requests to export per zone, 0 disables, defaults to 10")
+	viper.BindEnv("top_hosts")
+	viper.SetDefault("top_hosts", 10)
+
+	flags.Int("top_user_agents", 10, "number of top user agents by requests to export per zone, 0 disables, defaults to 10")
+	viper.BindEnv("top_user_agents")
+	viper.SetDefault("top_user_agents", 10)
+
+	flags.Int("top_asns", 10, "number of top client ASNs by requests and by threats to export per zone, 0 disables, defaults to 10")
+	viper.BindEnv("top_asns")
+	viper.SetDefault("top_asns", 10)
+
+	flags.Int("top_client_ips", 0, "number of top client IPs by requests to export per zone, 0 disables, defaults to 0")
+	viper.BindEnv("top_client_ips")
+	viper.SetDefault("top_client_ips", 0)
+
+	flags.Int("top_device_types", 10, "number of top client device types by requests to export per zone, 0 disables, defaults to 10")
+	viper.BindEnv("top_device_types")
+	viper.SetDefault("top_device_types", 10)
+
+	flags.Int("stream_top_videos", 10, "number of most viewed Stream videos to export per account, defaults to 10")
+	viper.BindEnv("stream_top_videos")
+	viper.SetDefault("stream_top_videos", 10)
+
 	flags.String("log_level", "info", "log level")
 	viper.BindEnv("log_level")
 	viper.SetDefault("log_level", "info")
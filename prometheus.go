@@ -1,13 +1,21 @@
 package main
 
 import (
-	"fmt"
+	"cmp"
+	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
-	"github.com/biter777/countries"
 	cfaccounts "github.com/cloudflare/cloudflare-go/v4/accounts"
+	cfapi_gateway "github.com/cloudflare/cloudflare-go/v4/api_gateway"
+	cflogpush "github.com/cloudflare/cloudflare-go/v4/logpush"
+	cfturnstile "github.com/cloudflare/cloudflare-go/v4/turnstile"
+	cfwaiting_rooms "github.com/cloudflare/cloudflare-go/v4/waiting_rooms"
+	cfworkers "github.com/cloudflare/cloudflare-go/v4/workers"
+	cfzero_trust "github.com/cloudflare/cloudflare-go/v4/zero_trust"
 	cfzones "github.com/cloudflare/cloudflare-go/v4/zones"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/viper"
@@ -20,46 +28,151 @@ func (mn MetricName) String() string {
 }
 
 const (
-	zoneRequestTotalMetricName                   MetricName = "cloudflare_zone_requests_total"
-	zoneRequestCachedMetricName                  MetricName = "cloudflare_zone_requests_cached"
-	zoneRequestSSLEncryptedMetricName            MetricName = "cloudflare_zone_requests_ssl_encrypted"
-	zoneRequestContentTypeMetricName             MetricName = "cloudflare_zone_requests_content_type"
-	zoneRequestCountryMetricName                 MetricName = "cloudflare_zone_requests_country"
-	zoneRequestHTTPStatusMetricName              MetricName = "cloudflare_zone_requests_status"
-	zoneRequestBrowserMapMetricName              MetricName = "cloudflare_zone_requests_browser_map_page_views_count"
-	zoneRequestOriginStatusCountryHostMetricName MetricName = "cloudflare_zone_requests_origin_status_country_host"
-	zoneRequestStatusCountryHostMetricName       MetricName = "cloudflare_zone_requests_status_country_host"
-	zoneBandwidthTotalMetricName                 MetricName = "cloudflare_zone_bandwidth_total"
-	zoneBandwidthCachedMetricName                MetricName = "cloudflare_zone_bandwidth_cached"
-	zoneBandwidthSSLEncryptedMetricName          MetricName = "cloudflare_zone_bandwidth_ssl_encrypted"
-	zoneBandwidthContentTypeMetricName           MetricName = "cloudflare_zone_bandwidth_content_type"
-	zoneBandwidthCountryMetricName               MetricName = "cloudflare_zone_bandwidth_country"
-	zoneThreatsTotalMetricName                   MetricName = "cloudflare_zone_threats_total"
-	zoneThreatsCountryMetricName                 MetricName = "cloudflare_zone_threats_country"
-	zoneThreatsTypeMetricName                    MetricName = "cloudflare_zone_threats_type"
-	zonePageviewsTotalMetricName                 MetricName = "cloudflare_zone_pageviews_total"
-	zoneUniquesTotalMetricName                   MetricName = "cloudflare_zone_uniques_total"
-	zoneColocationVisitsMetricName               MetricName = "cloudflare_zone_colocation_visits"
-	zoneColocationEdgeResponseBytesMetricName    MetricName = "cloudflare_zone_colocation_edge_response_bytes"
-	zoneColocationRequestsTotalMetricName        MetricName = "cloudflare_zone_colocation_requests_total"
-	zoneFirewallEventsCountMetricName            MetricName = "cloudflare_zone_firewall_events_count"
-	zoneHealthCheckEventsOriginCountMetricName   MetricName = "cloudflare_zone_health_check_events_origin_count"
-	workerRequestsMetricName                     MetricName = "cloudflare_worker_requests_count"
-	workerErrorsMetricName                       MetricName = "cloudflare_worker_errors_count"
-	workerCPUTimeMetricName                      MetricName = "cloudflare_worker_cpu_time"
-	workerDurationMetricName                     MetricName = "cloudflare_worker_duration"
-	poolHealthStatusMetricName                   MetricName = "cloudflare_zone_pool_health_status"
-	poolRequestsTotalMetricName                  MetricName = "cloudflare_zone_pool_requests_total"
-	poolOriginHealthStatusMetricName             MetricName = "cloudflare_pool_origin_health_status"
-	logpushFailedJobsAccountMetricName           MetricName = "cloudflare_logpush_failed_jobs_account_count"
-	logpushFailedJobsZoneMetricName              MetricName = "cloudflare_logpush_failed_jobs_zone_count"
-	r2StorageTotalMetricName                     MetricName = "cloudflare_r2_storage_total_bytes"
-	r2StorageMetricName                          MetricName = "cloudflare_r2_storage_bytes"
-	r2OperationMetricName                        MetricName = "cloudflare_r2_operation_count"
-	tunnelInfoMetricName                         MetricName = "cloudflare_tunnel_info"
-	tunnelHealthStatusMetricName                 MetricName = "cloudflare_tunnel_health_status"
-	tunnelConnectorInfoMetricName                MetricName = "cloudflare_tunnel_connector_info"
-	tunnelConnectorActiveConnectionsMetricName   MetricName = "cloudflare_tunnel_connector_active_connections"
+	zoneRequestTotalMetricName                      MetricName = "cloudflare_zone_requests_total"
+	zoneRequestCachedMetricName                     MetricName = "cloudflare_zone_requests_cached"
+	zoneRequestSSLEncryptedMetricName               MetricName = "cloudflare_zone_requests_ssl_encrypted"
+	zoneRequestContentTypeMetricName                MetricName = "cloudflare_zone_requests_content_type"
+	zoneRequestCountryMetricName                    MetricName = "cloudflare_zone_requests_country"
+	zoneRequestHTTPStatusMetricName                 MetricName = "cloudflare_zone_requests_status"
+	zoneRequestBrowserMapMetricName                 MetricName = "cloudflare_zone_requests_browser_map_page_views_count"
+	zoneRequestOriginStatusCountryHostMetricName    MetricName = "cloudflare_zone_requests_origin_status_country_host"
+	zoneRequestStatusCountryHostMetricName          MetricName = "cloudflare_zone_requests_status_country_host"
+	zoneBandwidthTotalMetricName                    MetricName = "cloudflare_zone_bandwidth_total"
+	zoneBandwidthCachedMetricName                   MetricName = "cloudflare_zone_bandwidth_cached"
+	zoneBandwidthSSLEncryptedMetricName             MetricName = "cloudflare_zone_bandwidth_ssl_encrypted"
+	zoneBandwidthContentTypeMetricName              MetricName = "cloudflare_zone_bandwidth_content_type"
+	zoneBandwidthCountryMetricName                  MetricName = "cloudflare_zone_bandwidth_country"
+	zoneThreatsTotalMetricName                      MetricName = "cloudflare_zone_threats_total"
+	zoneThreatsCountryMetricName                    MetricName = "cloudflare_zone_threats_country"
+	zoneThreatsTypeMetricName                       MetricName = "cloudflare_zone_threats_type"
+	zonePageviewsTotalMetricName                    MetricName = "cloudflare_zone_pageviews_total"
+	zoneUniquesTotalMetricName                      MetricName = "cloudflare_zone_uniques_total"
+	zoneColocationVisitsMetricName                  MetricName = "cloudflare_zone_colocation_visits"
+	zoneColocationEdgeResponseBytesMetricName       MetricName = "cloudflare_zone_colocation_edge_response_bytes"
+	zoneColocationRequestsTotalMetricName           MetricName = "cloudflare_zone_colocation_requests_total"
+	zoneFirewallEventsCountMetricName               MetricName = "cloudflare_zone_firewall_events_count"
+	zoneHealthCheckEventsOriginCountMetricName      MetricName = "cloudflare_zone_health_check_events_origin_count"
+	zoneBotRequestsCountMetricName                  MetricName = "cloudflare_zone_bot_requests_count"
+	zoneSecurityRuleHitsCountMetricName             MetricName = "cloudflare_zone_security_rule_hits_count"
+	zoneWAFOWASPEventsCountMetricName               MetricName = "cloudflare_zone_waf_owasp_events_count"
+	zoneWAFOWASPScoreThresholdMetricName            MetricName = "cloudflare_zone_waf_owasp_score_threshold"
+	zoneWAFAttackScoreRequestsCountMetricName       MetricName = "cloudflare_zone_waf_attack_score_requests_count"
+	zoneRulesetInfoMetricName                       MetricName = "cloudflare_zone_ruleset_info"
+	zoneAPIShieldDiscoveredEndpointsMetricName      MetricName = "cloudflare_zone_api_shield_discovered_endpoints"
+	zoneAPIShieldSchemaViolationsCountMetricName    MetricName = "cloudflare_zone_api_shield_schema_violations_count"
+	zoneAPIShieldSequenceMitigationCountMetricName  MetricName = "cloudflare_zone_api_shield_sequence_mitigation_count"
+	zonePageShieldScriptsMetricName                 MetricName = "cloudflare_zone_page_shield_scripts"
+	zonePageShieldConnectionsMetricName             MetricName = "cloudflare_zone_page_shield_connections"
+	zoneSpectrumBytesMetricName                     MetricName = "cloudflare_zone_spectrum_bytes"
+	zoneSpectrumPacketsMetricName                   MetricName = "cloudflare_zone_spectrum_packets"
+	zoneSpectrumActiveConnectionsMetricName         MetricName = "cloudflare_zone_spectrum_active_connections"
+	magicTransitBitsMetricName                      MetricName = "cloudflare_magic_transit_bits"
+	magicTransitPacketsMetricName                   MetricName = "cloudflare_magic_transit_packets"
+	zoneDDoSMitigatedRequestsCountMetricName        MetricName = "cloudflare_zone_ddos_mitigated_requests_count"
+	zoneDDoSAttackInProgressMetricName              MetricName = "cloudflare_zone_ddos_attack_in_progress"
+	zoneDDoSAttacksMetricName                       MetricName = "cloudflare_zone_ddos_attacks"
+	ddosAttacksMetricName                           MetricName = "cloudflare_ddos_attacks"
+	ddosPacketsMetricName                           MetricName = "cloudflare_ddos_packets"
+	ddosBitsMetricName                              MetricName = "cloudflare_ddos_bits"
+	ddosAttackInProgressMetricName                  MetricName = "cloudflare_ddos_attack_in_progress"
+	rumPageLoadsMetricName                          MetricName = "cloudflare_rum_page_loads"
+	rumLargestContentfulPaintMetricName             MetricName = "cloudflare_rum_largest_contentful_paint"
+	rumInteractionToNextPaintMetricName             MetricName = "cloudflare_rum_interaction_to_next_paint"
+	rumCumulativeLayoutShiftMetricName              MetricName = "cloudflare_rum_cumulative_layout_shift"
+	rumTimeToFirstByteMetricName                    MetricName = "cloudflare_rum_time_to_first_byte"
+	rumFirstContentfulPaintMetricName               MetricName = "cloudflare_rum_first_contentful_paint"
+	zoneWaitingRoomTotalActiveUsersLimitMetricName  MetricName = "cloudflare_zone_waiting_room_total_active_users_limit"
+	zoneWaitingRoomNewUsersPerMinuteLimitMetricName MetricName = "cloudflare_zone_waiting_room_new_users_per_minute_limit"
+	zoneWaitingRoomStatusMetricName                 MetricName = "cloudflare_zone_waiting_room_status"
+	zoneWaitingRoomQueuedUsersMetricName            MetricName = "cloudflare_zone_waiting_room_queued_users"
+	zoneWaitingRoomActiveUsersMetricName            MetricName = "cloudflare_zone_waiting_room_active_users"
+	zoneWaitingRoomEstimatedWaitTimeMetricName      MetricName = "cloudflare_zone_waiting_room_estimated_wait_time_minutes"
+	zoneWaitingRoomAdmittedUsersPerMinuteMetricName MetricName = "cloudflare_zone_waiting_room_admitted_users_per_minute"
+	zoneEmailRoutingMessagesMetricName              MetricName = "cloudflare_zone_email_routing_messages_count"
+	zoneEmailRoutingAuthResultsMetricName           MetricName = "cloudflare_zone_email_routing_auth_results_count"
+	zoneEmailRoutingRulesMetricName                 MetricName = "cloudflare_zone_email_routing_rules"
+	emailRoutingDestinationAddressesMetricName      MetricName = "cloudflare_email_routing_destination_addresses"
+	zoneArgoRequestsMetricName                      MetricName = "cloudflare_zone_argo_requests_count"
+	zoneArgoOriginResponseDurationMetricName        MetricName = "cloudflare_zone_argo_origin_response_duration_ms"
+	zoneArgoSmartRoutedRatioMetricName              MetricName = "cloudflare_zone_argo_smart_routed_ratio"
+	zoneTieredCacheRequestsMetricName               MetricName = "cloudflare_zone_tiered_cache_requests_count"
+	zoneTieredCacheUpperTierHitRatioMetricName      MetricName = "cloudflare_zone_tiered_cache_upper_tier_hit_ratio"
+	zoneTopPathRequestsMetricName                   MetricName = "cloudflare_zone_top_path_requests"
+	zoneTopPathBytesMetricName                      MetricName = "cloudflare_zone_top_path_bytes"
+	zoneTopHostRequestsMetricName                   MetricName = "cloudflare_zone_top_host_requests"
+	zoneTopHostBytesMetricName                      MetricName = "cloudflare_zone_top_host_bytes"
+	zoneTopUserAgentRequestsMetricName              MetricName = "cloudflare_zone_top_user_agent_requests"
+	zoneTopUserAgentBytesMetricName                 MetricName = "cloudflare_zone_top_user_agent_bytes"
+	zoneTopASNRequestsMetricName                    MetricName = "cloudflare_zone_top_asn_requests"
+	zoneTopASNBytesMetricName                       MetricName = "cloudflare_zone_top_asn_bytes"
+	zoneTopClientIPRequestsMetricName               MetricName = "cloudflare_zone_top_client_ip_requests"
+	zoneTopClientIPBytesMetricName                  MetricName = "cloudflare_zone_top_client_ip_bytes"
+	zoneTopDeviceTypeRequestsMetricName             MetricName = "cloudflare_zone_top_device_type_requests"
+	zoneTopDeviceTypeBytesMetricName                MetricName = "cloudflare_zone_top_device_type_bytes"
+	zoneTopASNThreatsMetricName                     MetricName = "cloudflare_zone_top_asn_threats"
+	zoneSampleIntervalMetricName                    MetricName = "cloudflare_zone_sample_interval"
+	workerRequestsMetricName                        MetricName = "cloudflare_worker_requests_count"
+	workerErrorsMetricName                          MetricName = "cloudflare_worker_errors_count"
+	workerCPUTimeMetricName                         MetricName = "cloudflare_worker_cpu_time"
+	workerDurationMetricName                        MetricName = "cloudflare_worker_duration"
+	workerWallTimeMetricName                        MetricName = "cloudflare_worker_wall_time"
+	workerSubrequestsMetricName                     MetricName = "cloudflare_worker_subrequests_count"
+	workerScriptInfoMetricName                      MetricName = "cloudflare_worker_script_info"
+	workerLastDeploymentMetricName                  MetricName = "cloudflare_worker_last_deployment_timestamp_seconds"
+	workerCustomDomainsMetricName                   MetricName = "cloudflare_worker_custom_domains"
+	workerRoutesMetricName                          MetricName = "cloudflare_worker_routes"
+	workerCronTriggerInfoMetricName                 MetricName = "cloudflare_worker_cron_trigger_info"
+	workerCronExecutionsMetricName                  MetricName = "cloudflare_worker_cron_executions_count"
+	aiGatewayRequestsMetricName                     MetricName = "cloudflare_ai_gateway_requests_count"
+	aiGatewayCachedRequestsMetricName               MetricName = "cloudflare_ai_gateway_cached_requests_count"
+	aiGatewayErrorsMetricName                       MetricName = "cloudflare_ai_gateway_errors_count"
+	aiGatewayTokensMetricName                       MetricName = "cloudflare_ai_gateway_tokens_count"
+	aiGatewayCostMetricName                         MetricName = "cloudflare_ai_gateway_cost_usd"
+	workersAIInferencesMetricName                   MetricName = "cloudflare_workers_ai_inferences_count"
+	workersAINeuronsMetricName                      MetricName = "cloudflare_workers_ai_neurons_count"
+	poolHealthStatusMetricName                      MetricName = "cloudflare_zone_pool_health_status"
+	poolRequestsTotalMetricName                     MetricName = "cloudflare_zone_pool_requests_total"
+	poolOriginHealthStatusMetricName                MetricName = "cloudflare_pool_origin_health_status"
+	logpushFailedJobsAccountMetricName              MetricName = "cloudflare_logpush_failed_jobs_account_count"
+	logpushFailedJobsZoneMetricName                 MetricName = "cloudflare_logpush_failed_jobs_zone_count"
+	logpushJobInfoMetricName                        MetricName = "cloudflare_logpush_job_info"
+	logpushJobLastCompleteMetricName                MetricName = "cloudflare_logpush_job_last_complete_timestamp_seconds"
+	logpushJobLastErrorMetricName                   MetricName = "cloudflare_logpush_job_last_error_timestamp_seconds"
+	logpushJobDeliveryLagMetricName                 MetricName = "cloudflare_logpush_job_delivery_lag_seconds"
+	logpushUploadsMetricName                        MetricName = "cloudflare_logpush_uploads_count"
+	logpushBytesMetricName                          MetricName = "cloudflare_logpush_bytes_count"
+	logpushRecordsMetricName                        MetricName = "cloudflare_logpush_records_count"
+	r2StorageTotalMetricName                        MetricName = "cloudflare_r2_storage_total_bytes"
+	r2StorageMetricName                             MetricName = "cloudflare_r2_storage_bytes"
+	r2OperationMetricName                           MetricName = "cloudflare_r2_operations_count"
+	r2ObjectsMetricName                             MetricName = "cloudflare_r2_objects"
+	r2MetadataStorageMetricName                     MetricName = "cloudflare_r2_metadata_storage_bytes"
+	r2EgressMetricName                              MetricName = "cloudflare_r2_egress_bytes"
+	estimatedCostMetricName                         MetricName = "cloudflare_estimated_cost_usd"
+	imagesStoredMetricName                          MetricName = "cloudflare_images_stored"
+	imagesStoredLimitMetricName                     MetricName = "cloudflare_images_stored_limit"
+	imagesVariantRequestsMetricName                 MetricName = "cloudflare_images_variant_requests"
+	imagesTransformationsMetricName                 MetricName = "cloudflare_images_transformations"
+	streamStorageMinutesMetricName                  MetricName = "cloudflare_stream_storage_minutes"
+	streamStorageMinutesLimitMetricName             MetricName = "cloudflare_stream_storage_minutes_limit"
+	streamVideosMetricName                          MetricName = "cloudflare_stream_videos"
+	streamMinutesDeliveredMetricName                MetricName = "cloudflare_stream_minutes_delivered"
+	streamVideoViewsMetricName                      MetricName = "cloudflare_stream_video_views"
+	streamVideoMinutesViewedMetricName              MetricName = "cloudflare_stream_video_minutes_viewed"
+	turnstileWidgetInfoMetricName                   MetricName = "cloudflare_turnstile_widget_info"
+	turnstileChallengesMetricName                   MetricName = "cloudflare_turnstile_challenges_count"
+	turnstileChallengeErrorsMetricName              MetricName = "cloudflare_turnstile_challenge_errors_count"
+	tunnelInfoMetricName                            MetricName = "cloudflare_tunnel_info"
+	tunnelHealthStatusMetricName                    MetricName = "cloudflare_tunnel_health_status"
+	tunnelConnectorInfoMetricName                   MetricName = "cloudflare_tunnel_connector_info"
+	tunnelConnectorActiveConnectionsMetricName      MetricName = "cloudflare_tunnel_connector_active_connections"
+	tunnelConnectorVersionSkewMetricName            MetricName = "cloudflare_tunnel_connector_version_skew"
+	tunnelConnectionInfoMetricName                  MetricName = "cloudflare_tunnel_connection_info"
+	tunnelConnectionAgeMetricName                   MetricName = "cloudflare_tunnel_connection_age_seconds"
+	tunnelConnectionPendingReconnectMetricName      MetricName = "cloudflare_tunnel_connection_pending_reconnect"
+	tunnelRequestsMetricName                        MetricName = "cloudflare_tunnel_requests_count"
+	tunnelBytesMetricName                           MetricName = "cloudflare_tunnel_bytes_count"
+	droppedSeriesMetricName                         MetricName = "cloudflare_exporter_dropped_series_count"
 )
 
 type MetricsSet map[MetricName]struct{}
@@ -75,244 +188,855 @@ func (ms MetricsSet) Add(mn MetricName) {
 
 var (
 	// Requests
-	zoneRequestTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneRequestTotal = newCounterVec(prometheus.CounterOpts{
 		Name: zoneRequestTotalMetricName.String(),
 		Help: "Number of requests for zone",
 	}, []string{"zone", "account"},
 	)
 
-	zoneRequestCached = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneRequestCached = newCounterVec(prometheus.CounterOpts{
 		Name: zoneRequestCachedMetricName.String(),
 		Help: "Number of cached requests for zone",
 	}, []string{"zone", "account"},
 	)
 
-	zoneRequestSSLEncrypted = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneRequestSSLEncrypted = newCounterVec(prometheus.CounterOpts{
 		Name: zoneRequestSSLEncryptedMetricName.String(),
 		Help: "Number of encrypted requests for zone",
 	}, []string{"zone", "account"},
 	)
 
-	zoneRequestContentType = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneRequestContentType = newCounterVec(prometheus.CounterOpts{
 		Name: zoneRequestContentTypeMetricName.String(),
 		Help: "Number of request for zone per content type",
 	}, []string{"zone", "account", "content_type"},
 	)
 
-	zoneRequestCountry = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneRequestCountry = newCounterVec(prometheus.CounterOpts{
 		Name: zoneRequestCountryMetricName.String(),
 		Help: "Number of request for zone per country",
-	}, []string{"zone", "account", "country", "region"},
+	}, []string{"zone", "account", "country", "continent", "subregion", "region"},
 	)
 
-	zoneRequestHTTPStatus = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneRequestHTTPStatus = newCounterVec(prometheus.CounterOpts{
 		Name: zoneRequestHTTPStatusMetricName.String(),
 		Help: "Number of request for zone per HTTP status",
 	}, []string{"zone", "account", "status"},
 	)
 
-	zoneRequestBrowserMap = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneRequestBrowserMap = newCounterVec(prometheus.CounterOpts{
 		Name: zoneRequestBrowserMapMetricName.String(),
 		Help: "Number of successful requests for HTML pages per zone",
 	}, []string{"zone", "account", "family"},
 	)
 
-	zoneRequestOriginStatusCountryHost = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneRequestOriginStatusCountryHost = newCounterVec(prometheus.CounterOpts{
 		Name: zoneRequestOriginStatusCountryHostMetricName.String(),
 		Help: "Count of not cached requests for zone per origin HTTP status per country per host",
-	}, []string{"zone", "account", "status", "country", "host"},
+	}, []string{"zone", "account", "status", "country", "continent", "subregion", "host", "estimated"},
 	)
 
-	zoneRequestStatusCountryHost = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneRequestStatusCountryHost = newCounterVec(prometheus.CounterOpts{
 		Name: zoneRequestStatusCountryHostMetricName.String(),
 		Help: "Count of requests for zone per edge HTTP status per country per host",
-	}, []string{"zone", "account", "status", "country", "host"},
+	}, []string{"zone", "account", "status", "country", "continent", "subregion", "host", "estimated"},
 	)
 
-	zoneBandwidthTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneBandwidthTotal = newCounterVec(prometheus.CounterOpts{
 		Name: zoneBandwidthTotalMetricName.String(),
 		Help: "Total bandwidth per zone in bytes",
 	}, []string{"zone", "account"},
 	)
 
-	zoneBandwidthCached = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneBandwidthCached = newCounterVec(prometheus.CounterOpts{
 		Name: zoneBandwidthCachedMetricName.String(),
 		Help: "Cached bandwidth per zone in bytes",
 	}, []string{"zone", "account"},
 	)
 
-	zoneBandwidthSSLEncrypted = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneBandwidthSSLEncrypted = newCounterVec(prometheus.CounterOpts{
 		Name: zoneBandwidthSSLEncryptedMetricName.String(),
 		Help: "Encrypted bandwidth per zone in bytes",
 	}, []string{"zone", "account"},
 	)
 
-	zoneBandwidthContentType = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneBandwidthContentType = newCounterVec(prometheus.CounterOpts{
 		Name: zoneBandwidthContentTypeMetricName.String(),
 		Help: "Bandwidth per zone per content type",
 	}, []string{"zone", "account", "content_type"},
 	)
 
-	zoneBandwidthCountry = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneBandwidthCountry = newCounterVec(prometheus.CounterOpts{
 		Name: zoneBandwidthCountryMetricName.String(),
 		Help: "Bandwidth per country per zone",
-	}, []string{"zone", "account", "country", "region"},
+	}, []string{"zone", "account", "country", "continent", "subregion", "region"},
 	)
 
-	zoneThreatsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneThreatsTotal = newCounterVec(prometheus.CounterOpts{
 		Name: zoneThreatsTotalMetricName.String(),
 		Help: "Threats per zone",
 	}, []string{"zone", "account"},
 	)
 
-	zoneThreatsCountry = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneThreatsCountry = newCounterVec(prometheus.CounterOpts{
 		Name: zoneThreatsCountryMetricName.String(),
 		Help: "Threats per zone per country",
-	}, []string{"zone", "account", "country", "region"},
+	}, []string{"zone", "account", "country", "continent", "subregion", "region"},
 	)
 
-	zoneThreatsType = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneThreatsType = newCounterVec(prometheus.CounterOpts{
 		Name: zoneThreatsTypeMetricName.String(),
 		Help: "Threats per zone per type",
 	}, []string{"zone", "account", "type"},
 	)
 
-	zonePageviewsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zonePageviewsTotal = newCounterVec(prometheus.CounterOpts{
 		Name: zonePageviewsTotalMetricName.String(),
 		Help: "Pageviews per zone",
 	}, []string{"zone", "account"},
 	)
 
-	zoneUniquesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneUniquesTotal = newCounterVec(prometheus.CounterOpts{
 		Name: zoneUniquesTotalMetricName.String(),
 		Help: "Uniques per zone",
 	}, []string{"zone", "account"},
 	)
 
-	zoneColocationVisits = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneColocationVisits = newCounterVec(prometheus.CounterOpts{
 		Name: zoneColocationVisitsMetricName.String(),
 		Help: "Total visits per colocation",
-	}, []string{"zone", "account", "colocation", "host"},
+	}, []string{"zone", "account", "colocation", "host", "estimated"},
 	)
 
-	zoneColocationEdgeResponseBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneColocationEdgeResponseBytes = newCounterVec(prometheus.CounterOpts{
 		Name: zoneColocationEdgeResponseBytesMetricName.String(),
 		Help: "Edge response bytes per colocation",
-	}, []string{"zone", "account", "colocation", "host"},
+	}, []string{"zone", "account", "colocation", "host", "estimated"},
 	)
 
-	zoneColocationRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneColocationRequestsTotal = newCounterVec(prometheus.CounterOpts{
 		Name: zoneColocationRequestsTotalMetricName.String(),
 		Help: "Total requests per colocation",
-	}, []string{"zone", "account", "colocation", "host"},
+	}, []string{"zone", "account", "colocation", "host", "estimated"},
 	)
 
-	zoneFirewallEventsCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneFirewallEventsCount = newCounterVec(prometheus.CounterOpts{
 		Name: zoneFirewallEventsCountMetricName.String(),
 		Help: "Count of Firewall events",
-	}, []string{"zone", "account", "action", "source", "rule", "host", "country"},
+	}, []string{"zone", "account", "action", "source", "rule", "host", "country", "continent", "subregion", "estimated"},
 	)
 
-	zoneHealthCheckEventsOriginCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneHealthCheckEventsOriginCount = newCounterVec(prometheus.CounterOpts{
 		Name: zoneHealthCheckEventsOriginCountMetricName.String(),
 		Help: "Number of Heath check events per region per origin",
 	}, []string{"zone", "account", "health_status", "origin_ip", "region", "fqdn"},
 	)
 
-	workerRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	zoneBotRequestsCount = newCounterVec(prometheus.CounterOpts{
+		Name: zoneBotRequestsCountMetricName.String(),
+		Help: "Number of requests per bot score bucket, bot management decision and verified bot category per host",
+	}, []string{"zone", "account", "host", "bot_score_bucket", "decision", "verified_bot_category", "estimated"},
+	)
+
+	zoneSecurityRuleHitsCount = newCounterVec(prometheus.CounterOpts{
+		Name: zoneSecurityRuleHitsCountMetricName.String(),
+		Help: "Number of security events per WAF, custom and rate limiting rule",
+	}, []string{"zone", "account", "phase", "ruleset_id", "ruleset", "rule_id", "rule", "source", "action", "estimated"},
+	)
+
+	zoneWAFOWASPEventsCount = newCounterVec(prometheus.CounterOpts{
+		Name: zoneWAFOWASPEventsCountMetricName.String(),
+		Help: "Number of security events raised by the Cloudflare OWASP Core Ruleset",
+	}, []string{"zone", "account", "rule_id", "rule", "action", "estimated"},
+	)
+
+	zoneWAFAttackScoreRequestsCount = newCounterVec(prometheus.CounterOpts{
+		Name: zoneWAFAttackScoreRequestsCountMetricName.String(),
+		Help: "Number of requests per WAF attack score bucket",
+	}, []string{"zone", "account", "bucket", "estimated"},
+	)
+
+	zoneWAFOWASPScoreThreshold = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneWAFOWASPScoreThresholdMetricName.String(),
+		Help: "Anomaly score threshold of the Cloudflare OWASP Core Ruleset per deploying ruleset, 0 when the ruleset default is used",
+	}, []string{"zone", "account", "ruleset_id"},
+	)
+
+	zoneRulesetInfo = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneRulesetInfoMetricName.String(),
+		Help: "Reports the deployed version of WAF, custom and rate limiting rulesets",
+	}, []string{"zone", "account", "ruleset_id", "ruleset", "phase", "kind", "version"},
+	)
+
+	zoneAPIShieldDiscoveredEndpoints = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneAPIShieldDiscoveredEndpointsMetricName.String(),
+		Help: "Number of API endpoints discovered by API Shield per review state",
+	}, []string{"zone", "account", "state"},
+	)
+
+	zoneAPIShieldSchemaViolationsCount = newCounterVec(prometheus.CounterOpts{
+		Name: zoneAPIShieldSchemaViolationsCountMetricName.String(),
+		Help: "Number of API Shield schema validation violations per endpoint and operation",
+	}, []string{"zone", "account", "host", "method", "endpoint", "operation_id", "action", "estimated"},
+	)
+
+	zoneAPIShieldSequenceMitigationCount = newCounterVec(prometheus.CounterOpts{
+		Name: zoneAPIShieldSequenceMitigationCountMetricName.String(),
+		Help: "Number of API Shield sequence mitigation hits per endpoint and operation",
+	}, []string{"zone", "account", "host", "method", "endpoint", "operation_id", "action", "estimated"},
+	)
+
+	zonePageShieldScripts = newGaugeVec(prometheus.GaugeOpts{
+		Name: zonePageShieldScriptsMetricName.String(),
+		Help: "Number of scripts detected by Page Shield per status (total, malicious, new, changed), new and changed scripts were first seen or changed within the last 24 hours",
+	}, []string{"zone", "account", "status"},
+	)
+
+	zonePageShieldConnections = newGaugeVec(prometheus.GaugeOpts{
+		Name: zonePageShieldConnectionsMetricName.String(),
+		Help: "Number of connections detected by Page Shield per status (total, malicious, new)",
+	}, []string{"zone", "account", "status"},
+	)
+
+	zoneSpectrumBytes = newCounterVec(prometheus.CounterOpts{
+		Name: zoneSpectrumBytesMetricName.String(),
+		Help: "Bytes transferred by Spectrum applications per colocation",
+	}, []string{"zone", "account", "app_id", "colocation", "protocol", "estimated"},
+	)
+
+	zoneSpectrumPackets = newCounterVec(prometheus.CounterOpts{
+		Name: zoneSpectrumPacketsMetricName.String(),
+		Help: "Packets transferred by Spectrum applications per colocation",
+	}, []string{"zone", "account", "app_id", "colocation", "protocol", "estimated"},
+	)
+
+	zoneSpectrumActiveConnections = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneSpectrumActiveConnectionsMetricName.String(),
+		Help: "Number of currently open connections per Spectrum application",
+	}, []string{"zone", "account", "app_id"},
+	)
+
+	magicTransitBits = newCounterVec(prometheus.CounterOpts{
+		Name: magicTransitBitsMetricName.String(),
+		Help: "Bits received by Magic Transit per prefix, protocol, colocation and mitigation outcome",
+	}, []string{"account", "prefix", "protocol", "colocation", "outcome", "estimated"},
+	)
+
+	magicTransitPackets = newCounterVec(prometheus.CounterOpts{
+		Name: magicTransitPacketsMetricName.String(),
+		Help: "Packets received by Magic Transit per prefix, protocol, colocation and mitigation outcome",
+	}, []string{"account", "prefix", "protocol", "colocation", "outcome", "estimated"},
+	)
+
+	zoneDDoSMitigatedRequestsCount = newCounterVec(prometheus.CounterOpts{
+		Name: zoneDDoSMitigatedRequestsCountMetricName.String(),
+		Help: "Number of requests mitigated by the HTTP DDoS attack protection per attack vector, rule and action",
+	}, []string{"zone", "account", "attack_vector", "rule_id", "action", "estimated"},
+	)
+
+	zoneDDoSAttacks = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneDDoSAttacksMetricName.String(),
+		Help: "Number of distinct HTTP DDoS attacks mitigated for the zone per attack vector",
+	}, []string{"zone", "account", "attack_vector"},
+	)
+
+	zoneDDoSAttackInProgress = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneDDoSAttackInProgressMetricName.String(),
+		Help: "Reports whether an HTTP DDoS attack is being mitigated for the zone, 1 for attack in progress, 0 otherwise",
+	}, []string{"zone", "account"},
+	)
+
+	ddosAttacks = newGaugeVec(prometheus.GaugeOpts{
+		Name: ddosAttacksMetricName.String(),
+		Help: "Number of distinct L3/4 DDoS attacks mitigated per attack vector",
+	}, []string{"account", "attack_vector"},
+	)
+
+	ddosPackets = newCounterVec(prometheus.CounterOpts{
+		Name: ddosPacketsMetricName.String(),
+		Help: "Packets handled by the L3/4 DDoS attack protection per attack vector, rule and outcome",
+	}, []string{"account", "attack_vector", "rule_id", "outcome", "estimated"},
+	)
+
+	ddosBits = newCounterVec(prometheus.CounterOpts{
+		Name: ddosBitsMetricName.String(),
+		Help: "Bits handled by the L3/4 DDoS attack protection per attack vector, rule and outcome",
+	}, []string{"account", "attack_vector", "rule_id", "outcome", "estimated"},
+	)
+
+	ddosAttackInProgress = newGaugeVec(prometheus.GaugeOpts{
+		Name: ddosAttackInProgressMetricName.String(),
+		Help: "Reports whether an L3/4 DDoS attack is being mitigated for the account, 1 for attack in progress, 0 otherwise",
+	}, []string{"account"},
+	)
+
+	rumPageLoads = newCounterVec(prometheus.CounterOpts{
+		Name: rumPageLoadsMetricName.String(),
+		Help: "Number of page loads reported by Web Analytics per site, path class, country and device type",
+	}, []string{"account", "site_tag", "path_class", "country", "continent", "subregion", "device_type"},
+	)
+
+	rumLargestContentfulPaint = newGaugeVec(prometheus.GaugeOpts{
+		Name: rumLargestContentfulPaintMetricName.String(),
+		Help: "Estimated Largest Contentful Paint quantiles per site, path class, country and device type, averaged over the paths of the class weighted by page loads",
+	}, []string{"account", "site_tag", "path_class", "country", "continent", "subregion", "device_type", "quantile"},
+	)
+
+	rumInteractionToNextPaint = newGaugeVec(prometheus.GaugeOpts{
+		Name: rumInteractionToNextPaintMetricName.String(),
+		Help: "Estimated Interaction to Next Paint quantiles per site, path class, country and device type, averaged over the paths of the class weighted by page loads",
+	}, []string{"account", "site_tag", "path_class", "country", "continent", "subregion", "device_type", "quantile"},
+	)
+
+	rumCumulativeLayoutShift = newGaugeVec(prometheus.GaugeOpts{
+		Name: rumCumulativeLayoutShiftMetricName.String(),
+		Help: "Estimated Cumulative Layout Shift quantiles per site, path class, country and device type, averaged over the paths of the class weighted by page loads",
+	}, []string{"account", "site_tag", "path_class", "country", "continent", "subregion", "device_type", "quantile"},
+	)
+
+	rumTimeToFirstByte = newGaugeVec(prometheus.GaugeOpts{
+		Name: rumTimeToFirstByteMetricName.String(),
+		Help: "Estimated Time to First Byte quantiles per site, path class, country and device type, averaged over the paths of the class weighted by page loads",
+	}, []string{"account", "site_tag", "path_class", "country", "continent", "subregion", "device_type", "quantile"},
+	)
+
+	rumFirstContentfulPaint = newGaugeVec(prometheus.GaugeOpts{
+		Name: rumFirstContentfulPaintMetricName.String(),
+		Help: "Estimated First Contentful Paint quantiles per site, path class, country and device type, averaged over the paths of the class weighted by page loads",
+	}, []string{"account", "site_tag", "path_class", "country", "continent", "subregion", "device_type", "quantile"},
+	)
+
+	zoneWaitingRoomTotalActiveUsersLimit = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneWaitingRoomTotalActiveUsersLimitMetricName.String(),
+		Help: "Configured maximum number of active users per waiting room",
+	}, []string{"zone", "account", "waiting_room", "host"},
+	)
+
+	zoneWaitingRoomNewUsersPerMinuteLimit = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneWaitingRoomNewUsersPerMinuteLimitMetricName.String(),
+		Help: "Configured number of new users admitted per minute per waiting room",
+	}, []string{"zone", "account", "waiting_room", "host"},
+	)
+
+	zoneWaitingRoomStatus = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneWaitingRoomStatusMetricName.String(),
+		Help: "Reports the current status of a waiting room (queueing, not_queueing, event_prequeueing, suspended)",
+	}, []string{"zone", "account", "waiting_room", "host", "status"},
+	)
+
+	zoneWaitingRoomQueuedUsers = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneWaitingRoomQueuedUsersMetricName.String(),
+		Help: "Number of users waiting in the queue per waiting room",
+	}, []string{"zone", "account", "waiting_room", "host"},
+	)
+
+	zoneWaitingRoomActiveUsers = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneWaitingRoomActiveUsersMetricName.String(),
+		Help: "Number of active users on the origin per waiting room",
+	}, []string{"zone", "account", "waiting_room", "host"},
+	)
+
+	zoneWaitingRoomEstimatedWaitTime = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneWaitingRoomEstimatedWaitTimeMetricName.String(),
+		Help: "Estimated wait time in minutes per waiting room",
+	}, []string{"zone", "account", "waiting_room", "host"},
+	)
+
+	zoneWaitingRoomAdmittedUsersPerMinute = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneWaitingRoomAdmittedUsersPerMinuteMetricName.String(),
+		Help: "Number of users admitted to the origin per minute per waiting room",
+	}, []string{"zone", "account", "waiting_room", "host"},
+	)
+
+	zoneEmailRoutingMessages = newCounterVec(prometheus.CounterOpts{
+		Name: zoneEmailRoutingMessagesMetricName.String(),
+		Help: "Number of emails processed by Email Routing per rule, action and status (delivered, dropped, rejected)",
+	}, []string{"zone", "account", "rule", "action", "status", "estimated"},
+	)
+
+	zoneEmailRoutingAuthResults = newCounterVec(prometheus.CounterOpts{
+		Name: zoneEmailRoutingAuthResultsMetricName.String(),
+		Help: "Number of emails processed by Email Routing per status and SPF, DKIM and DMARC outcome",
+	}, []string{"zone", "account", "status", "spf", "dkim", "dmarc", "estimated"},
+	)
+
+	zoneEmailRoutingRules = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneEmailRoutingRulesMetricName.String(),
+		Help: "Number of Email Routing rules configured",
+	}, []string{"zone", "account", "enabled"},
+	)
+
+	emailRoutingDestinationAddresses = newGaugeVec(prometheus.GaugeOpts{
+		Name: emailRoutingDestinationAddressesMetricName.String(),
+		Help: "Number of Email Routing destination addresses",
+	}, []string{"account", "verified"},
+	)
+
+	zoneArgoRequests = newCounterVec(prometheus.CounterOpts{
+		Name: zoneArgoRequestsMetricName.String(),
+		Help: "Number of requests sent to origin with and without Argo Smart Routing",
+	}, []string{"zone", "account", "origin", "smart_routed", "estimated"},
+	)
+
+	zoneArgoOriginResponseDuration = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneArgoOriginResponseDurationMetricName.String(),
+		Help: "Average origin response time in milliseconds with and without Argo Smart Routing",
+	}, []string{"zone", "account", "origin", "smart_routed"},
+	)
+
+	zoneArgoSmartRoutedRatio = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneArgoSmartRoutedRatioMetricName.String(),
+		Help: "Ratio of origin requests routed by Argo Smart Routing",
+	}, []string{"zone", "account", "origin"},
+	)
+
+	zoneTieredCacheRequests = newCounterVec(prometheus.CounterOpts{
+		Name: zoneTieredCacheRequestsMetricName.String(),
+		Help: "Number of requests served through a Tiered Cache upper tier by cache status",
+	}, []string{"zone", "account", "upper_tier", "cache_status", "estimated"},
+	)
+
+	zoneTieredCacheUpperTierHitRatio = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneTieredCacheUpperTierHitRatioMetricName.String(),
+		Help: "Ratio of requests served from cache through a Tiered Cache upper tier",
+	}, []string{"zone", "account", "upper_tier"},
+	)
+
+	zoneTopPathRequests = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneTopPathRequestsMetricName.String(),
+		Help: "Number of requests in the last scrape window for the top N request paths",
+	}, []string{"zone", "account", "path", "estimated"})
+
+	zoneTopPathBytes = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneTopPathBytesMetricName.String(),
+		Help: "Number of bytes served in the last scrape window for the top N request paths by bytes",
+	}, []string{"zone", "account", "path", "estimated"})
+
+	zoneTopHostRequests = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneTopHostRequestsMetricName.String(),
+		Help: "Number of requests in the last scrape window for the top N hosts",
+	}, []string{"zone", "account", "host", "estimated"})
+
+	zoneTopHostBytes = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneTopHostBytesMetricName.String(),
+		Help: "Number of bytes served in the last scrape window for the top N hosts by bytes",
+	}, []string{"zone", "account", "host", "estimated"})
+
+	zoneTopUserAgentRequests = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneTopUserAgentRequestsMetricName.String(),
+		Help: "Number of requests in the last scrape window for the top N user agents",
+	}, []string{"zone", "account", "user_agent", "estimated"})
+
+	zoneTopUserAgentBytes = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneTopUserAgentBytesMetricName.String(),
+		Help: "Number of bytes served in the last scrape window for the top N user agents by bytes",
+	}, []string{"zone", "account", "user_agent", "estimated"})
+
+	zoneTopASNRequests = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneTopASNRequestsMetricName.String(),
+		Help: "Number of requests in the last scrape window for the top N client ASNs",
+	}, []string{"zone", "account", "asn", "asn_description", "estimated"})
+
+	zoneTopASNBytes = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneTopASNBytesMetricName.String(),
+		Help: "Number of bytes served in the last scrape window for the top N client ASNs by bytes",
+	}, []string{"zone", "account", "asn", "asn_description", "estimated"})
+
+	zoneTopClientIPRequests = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneTopClientIPRequestsMetricName.String(),
+		Help: "Number of requests in the last scrape window for the top N client IPs",
+	}, []string{"zone", "account", "client_ip", "estimated"})
+
+	zoneTopClientIPBytes = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneTopClientIPBytesMetricName.String(),
+		Help: "Number of bytes served in the last scrape window for the top N client IPs by bytes",
+	}, []string{"zone", "account", "client_ip", "estimated"})
+
+	zoneTopDeviceTypeRequests = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneTopDeviceTypeRequestsMetricName.String(),
+		Help: "Number of requests in the last scrape window for the top N client device types",
+	}, []string{"zone", "account", "device_type", "estimated"})
+
+	zoneTopDeviceTypeBytes = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneTopDeviceTypeBytesMetricName.String(),
+		Help: "Number of bytes served in the last scrape window for the top N client device types by bytes",
+	}, []string{"zone", "account", "device_type", "estimated"})
+
+	zoneTopASNThreats = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneTopASNThreatsMetricName.String(),
+		Help: "Number of blocked or challenged requests in the last scrape window for the top N client ASNs by threats",
+	}, []string{"zone", "account", "asn", "asn_description", "estimated"})
+
+	zoneSampleInterval = newGaugeVec(prometheus.GaugeOpts{
+		Name: zoneSampleIntervalMetricName.String(),
+		Help: "Average sample interval of an adaptive dataset in the last scrape window, 1 when not sampled",
+	}, []string{"zone", "account", "dataset"})
+
+	workerRequests = newCounterVec(prometheus.CounterOpts{
 		Name: workerRequestsMetricName.String(),
 		Help: "Number of requests sent to worker by script name",
 	}, []string{"script_name", "account", "status"},
 	)
 
-	workerErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+	workerErrors = newCounterVec(prometheus.CounterOpts{
 		Name: workerErrorsMetricName.String(),
 		Help: "Number of errors by script name",
 	}, []string{"script_name", "account", "status"},
 	)
 
-	workerCPUTime = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	workerCPUTime = newGaugeVec(prometheus.GaugeOpts{
 		Name: workerCPUTimeMetricName.String(),
 		Help: "CPU time quantiles by script name",
 	}, []string{"script_name", "account", "status", "quantile"},
 	)
 
-	workerDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	workerDuration = newGaugeVec(prometheus.GaugeOpts{
 		Name: workerDurationMetricName.String(),
 		Help: "Duration quantiles by script name (GB*s)",
 	}, []string{"script_name", "account", "status", "quantile"},
 	)
 
-	poolHealthStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	workerWallTime = newGaugeVec(prometheus.GaugeOpts{
+		Name: workerWallTimeMetricName.String(),
+		Help: "Wall time quantiles by script name",
+	}, []string{"script_name", "account", "status", "quantile"},
+	)
+
+	// Summary alternatives to the quantile gauges, see worker_latency_type
+	workerCPUTimeSummary  = newWorkerLatencySummary(workerCPUTimeMetricName, "CPU time by script name (microseconds)")
+	workerDurationSummary = newWorkerLatencySummary(workerDurationMetricName, "Duration by script name (GB*s)")
+	workerWallTimeSummary = newWorkerLatencySummary(workerWallTimeMetricName, "Wall time by script name (microseconds)")
+
+	workerSubrequests = newCounterVec(prometheus.CounterOpts{
+		Name: workerSubrequestsMetricName.String(),
+		Help: "Number of subrequests made by worker by script name",
+	}, []string{"script_name", "account", "status"},
+	)
+
+	workerScriptInfo = newGaugeVec(prometheus.GaugeOpts{
+		Name: workerScriptInfoMetricName.String(),
+		Help: "Reports the deployed version, compatibility date and usage model of a worker script",
+	}, []string{"script_name", "account", "version_id", "compatibility_date", "usage_model"},
+	)
+
+	workerLastDeployment = newGaugeVec(prometheus.GaugeOpts{
+		Name: workerLastDeploymentMetricName.String(),
+		Help: "Unix timestamp of the last deployment of a worker script",
+	}, []string{"script_name", "account"},
+	)
+
+	workerCustomDomains = newGaugeVec(prometheus.GaugeOpts{
+		Name: workerCustomDomainsMetricName.String(),
+		Help: "Number of custom domains attached to a worker script",
+	}, []string{"script_name", "account"},
+	)
+
+	workerRoutes = newGaugeVec(prometheus.GaugeOpts{
+		Name: workerRoutesMetricName.String(),
+		Help: "Number of zone routes attached to a worker script",
+	}, []string{"script_name", "zone", "account"},
+	)
+
+	workerCronTriggerInfo = newGaugeVec(prometheus.GaugeOpts{
+		Name: workerCronTriggerInfoMetricName.String(),
+		Help: "Reports the cron triggers configured for a worker script",
+	}, []string{"script_name", "account", "cron"},
+	)
+
+	workerCronExecutions = newCounterVec(prometheus.CounterOpts{
+		Name: workerCronExecutionsMetricName.String(),
+		Help: "Number of cron trigger executions by script name, cron and status",
+	}, []string{"script_name", "account", "cron", "status"},
+	)
+
+	aiGatewayRequests = newCounterVec(prometheus.CounterOpts{
+		Name: aiGatewayRequestsMetricName.String(),
+		Help: "Number of requests sent through AI Gateway by gateway, provider and model",
+	}, []string{"account", "gateway", "provider", "model"},
+	)
+
+	aiGatewayCachedRequests = newCounterVec(prometheus.CounterOpts{
+		Name: aiGatewayCachedRequestsMetricName.String(),
+		Help: "Number of AI Gateway requests served from cache by gateway, provider and model",
+	}, []string{"account", "gateway", "provider", "model"},
+	)
+
+	aiGatewayErrors = newCounterVec(prometheus.CounterOpts{
+		Name: aiGatewayErrorsMetricName.String(),
+		Help: "Number of failed AI Gateway requests by gateway, provider and model",
+	}, []string{"account", "gateway", "provider", "model"},
+	)
+
+	aiGatewayTokens = newCounterVec(prometheus.CounterOpts{
+		Name: aiGatewayTokensMetricName.String(),
+		Help: "Number of tokens processed by AI Gateway by gateway, provider, model and direction",
+	}, []string{"account", "gateway", "provider", "model", "direction"},
+	)
+
+	aiGatewayCost = newCounterVec(prometheus.CounterOpts{
+		Name: aiGatewayCostMetricName.String(),
+		Help: "Estimated cost in USD of AI Gateway requests by gateway, provider and model",
+	}, []string{"account", "gateway", "provider", "model"},
+	)
+
+	workersAIInferences = newCounterVec(prometheus.CounterOpts{
+		Name: workersAIInferencesMetricName.String(),
+		Help: "Number of Workers AI inference requests by model",
+	}, []string{"account", "model"},
+	)
+
+	workersAINeurons = newCounterVec(prometheus.CounterOpts{
+		Name: workersAINeuronsMetricName.String(),
+		Help: "Number of neurons consumed by Workers AI by model",
+	}, []string{"account", "model"},
+	)
+
+	poolHealthStatus = newGaugeVec(prometheus.GaugeOpts{
 		Name: poolHealthStatusMetricName.String(),
 		Help: "Reports the health of a pool, 1 for healthy, 0 for unhealthy.",
 	},
 		[]string{"zone", "account", "load_balancer_name", "pool_name"},
 	)
 
-	poolOriginHealthStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	poolOriginHealthStatus = newGaugeVec(prometheus.GaugeOpts{
 		Name: poolOriginHealthStatusMetricName.String(),
 		Help: "Reports the origin health of a pool, 1 for healthy, 0 for unhealthy.",
 	},
 		[]string{"account", "pool_name", "origin_name", "ip"},
 	)
 
-	poolRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	poolRequestsTotal = newCounterVec(prometheus.CounterOpts{
 		Name: poolRequestsTotalMetricName.String(),
 		Help: "Requests per pool",
 	},
-		[]string{"zone", "account", "load_balancer_name", "pool_name", "origin_name"},
+		[]string{"zone", "account", "load_balancer_name", "pool_name", "origin_name", "estimated"},
 	)
 
-	// TODO: Update this to counter vec and use counts from the query to add
-	logpushFailedJobsAccount = prometheus.NewCounterVec(prometheus.CounterOpts{
+	logpushFailedJobsAccount = newCounterVec(prometheus.CounterOpts{
 		Name: logpushFailedJobsAccountMetricName.String(),
 		Help: "Number of failed logpush jobs on the account level",
 	},
-		[]string{"account", "destination", "job_id", "final"},
+		[]string{"account", "job", "destination", "job_id", "final"},
 	)
 
-	logpushFailedJobsZone = prometheus.NewCounterVec(prometheus.CounterOpts{
+	logpushFailedJobsZone = newCounterVec(prometheus.CounterOpts{
 		Name: logpushFailedJobsZoneMetricName.String(),
 		Help: "Number of failed logpush jobs on the zone level",
 	},
-		[]string{"destination", "job_id", "final"},
+		[]string{"zone", "account", "job", "destination", "job_id", "final"},
+	)
+
+	logpushJobInfo = newGaugeVec(prometheus.GaugeOpts{
+		Name: logpushJobInfoMetricName.String(),
+		Help: "Reports the configuration of a logpush job",
+	},
+		[]string{"zone", "account", "job", "job_id", "dataset", "destination", "enabled"},
+	)
+
+	logpushJobLastComplete = newGaugeVec(prometheus.GaugeOpts{
+		Name: logpushJobLastCompleteMetricName.String(),
+		Help: "Unix timestamp of the last successful logpush job push",
+	},
+		[]string{"zone", "account", "job", "job_id"},
+	)
+
+	logpushJobLastError = newGaugeVec(prometheus.GaugeOpts{
+		Name: logpushJobLastErrorMetricName.String(),
+		Help: "Unix timestamp of the last failed logpush job push",
+	},
+		[]string{"zone", "account", "job", "job_id"},
+	)
+
+	logpushJobDeliveryLag = newGaugeVec(prometheus.GaugeOpts{
+		Name: logpushJobDeliveryLagMetricName.String(),
+		Help: "Seconds since the last successful push of an enabled logpush job",
+	},
+		[]string{"zone", "account", "job", "job_id"},
+	)
+
+	logpushUploads = newCounterVec(prometheus.CounterOpts{
+		Name: logpushUploadsMetricName.String(),
+		Help: "Number of logpush uploads by destination response status",
+	},
+		[]string{"zone", "account", "job", "job_id", "destination", "status"},
+	)
+
+	logpushBytes = newCounterVec(prometheus.CounterOpts{
+		Name: logpushBytesMetricName.String(),
+		Help: "Number of bytes pushed by logpush jobs",
+	},
+		[]string{"zone", "account", "job", "job_id", "destination"},
 	)
 
-	r2StorageTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	logpushRecords = newCounterVec(prometheus.CounterOpts{
+		Name: logpushRecordsMetricName.String(),
+		Help: "Number of records pushed by logpush jobs",
+	},
+		[]string{"zone", "account", "job", "job_id", "destination"},
+	)
+
+	r2StorageTotal = newGaugeVec(prometheus.GaugeOpts{
 		Name: r2StorageTotalMetricName.String(),
 		Help: "Total storage used by R2",
 	}, []string{"account"})
 
-	r2Storage = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	r2Storage = newGaugeVec(prometheus.GaugeOpts{
 		Name: r2StorageMetricName.String(),
 		Help: "Storage used by R2",
-	}, []string{"account", "bucket"})
+	}, []string{"account", "bucket", "storage_class"})
+
+	r2Objects = newGaugeVec(prometheus.GaugeOpts{
+		Name: r2ObjectsMetricName.String(),
+		Help: "Number of objects stored in R2",
+	}, []string{"account", "bucket", "storage_class"})
 
-	r2Operation = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	r2MetadataStorage = newGaugeVec(prometheus.GaugeOpts{
+		Name: r2MetadataStorageMetricName.String(),
+		Help: "Metadata storage used by R2",
+	}, []string{"account", "bucket", "storage_class"})
+
+	r2Operation = newCounterVec(prometheus.CounterOpts{
 		Name: r2OperationMetricName.String(),
-		Help: "Number of operations performed by R2",
-	}, []string{"account", "bucket", "operation"})
+		Help: "Number of operations performed by R2 by billing class (A, B, free)",
+	}, []string{"account", "bucket", "storage_class", "operation", "class", "status"})
+
+	r2Egress = newCounterVec(prometheus.CounterOpts{
+		Name: r2EgressMetricName.String(),
+		Help: "Number of bytes served by R2 object reads",
+	}, []string{"account", "bucket", "storage_class"})
+
+	droppedSeries = newCounterVec(prometheus.CounterOpts{
+		Name: droppedSeriesMetricName.String(),
+		Help: "Number of series folded into the other series by metrics_series_limit, summed over every scrape",
+	}, []string{"metric"})
+
+	estimatedCost = newGaugeVec(prometheus.GaugeOpts{
+		Name: estimatedCostMetricName.String(),
+		Help: "Estimated cost in USD accumulated over the current billing month per usage dimension since the exporter started, kept in memory and reset by a restart",
+	}, []string{"account", "zone", "script", "dimension"})
+
+	imagesStored = newGaugeVec(prometheus.GaugeOpts{
+		Name: imagesStoredMetricName.String(),
+		Help: "Number of images stored by Cloudflare Images",
+	}, []string{"account"})
+
+	imagesStoredLimit = newGaugeVec(prometheus.GaugeOpts{
+		Name: imagesStoredLimitMetricName.String(),
+		Help: "Number of images allowed to be stored by Cloudflare Images",
+	}, []string{"account"})
+
+	imagesVariantRequests = newGaugeVec(prometheus.GaugeOpts{
+		Name: imagesVariantRequestsMetricName.String(),
+		Help: "Number of images served per variant today",
+	}, []string{"account", "variant"})
+
+	imagesTransformations = newGaugeVec(prometheus.GaugeOpts{
+		Name: imagesTransformationsMetricName.String(),
+		Help: "Number of unique image transformations per type today",
+	}, []string{"account", "type"})
+
+	streamStorageMinutes = newGaugeVec(prometheus.GaugeOpts{
+		Name: streamStorageMinutesMetricName.String(),
+		Help: "Minutes of video stored by Cloudflare Stream",
+	}, []string{"account"})
+
+	streamStorageMinutesLimit = newGaugeVec(prometheus.GaugeOpts{
+		Name: streamStorageMinutesLimitMetricName.String(),
+		Help: "Minutes of video allowed to be stored by Cloudflare Stream",
+	}, []string{"account"})
+
+	streamVideos = newGaugeVec(prometheus.GaugeOpts{
+		Name: streamVideosMetricName.String(),
+		Help: "Number of videos stored by Cloudflare Stream",
+	}, []string{"account"})
 
-	tunnelInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	streamMinutesDelivered = newGaugeVec(prometheus.GaugeOpts{
+		Name: streamMinutesDeliveredMetricName.String(),
+		Help: "Minutes of video delivered by Cloudflare Stream today",
+	}, []string{"account"})
+
+	streamVideoViews = newGaugeVec(prometheus.GaugeOpts{
+		Name: streamVideoViewsMetricName.String(),
+		Help: "Number of views today for the most viewed videos",
+	}, []string{"account", "video_id"})
+
+	streamVideoMinutesViewed = newGaugeVec(prometheus.GaugeOpts{
+		Name: streamVideoMinutesViewedMetricName.String(),
+		Help: "Minutes viewed today for the most viewed videos",
+	}, []string{"account", "video_id"})
+
+	turnstileWidgetInfo = newGaugeVec(prometheus.GaugeOpts{
+		Name: turnstileWidgetInfoMetricName.String(),
+		Help: "Reports the configuration of a Turnstile widget",
+	}, []string{"account", "sitekey", "widget", "mode"})
+
+	turnstileChallenges = newCounterVec(prometheus.CounterOpts{
+		Name: turnstileChallengesMetricName.String(),
+		Help: "Number of Turnstile challenge events (issued, solved, failed) per widget and action",
+	}, []string{"account", "sitekey", "widget", "action", "event"})
+
+	turnstileChallengeErrors = newCounterVec(prometheus.CounterOpts{
+		Name: turnstileChallengeErrorsMetricName.String(),
+		Help: "Number of failed Turnstile challenges per widget and error code",
+	}, []string{"account", "sitekey", "widget", "error_code"})
+
+	tunnelInfo = newGaugeVec(prometheus.GaugeOpts{
 		Name: tunnelInfoMetricName.String(),
 		Help: "Reports Cloudflare Tunnel details",
 	}, []string{"account", "tunnel_id", "tunnel_name", "tunnel_type"})
 
-	tunnelHealthStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	tunnelHealthStatus = newGaugeVec(prometheus.GaugeOpts{
 		Name: tunnelHealthStatusMetricName.String(),
 		Help: "Reports the health of a Cloudflare Tunnel, 0 for unhealthy, 1 for healthy, 2 for degraded, 3 for inactive",
 	}, []string{"account", "tunnel_id"})
 
-	tunnelConnectorInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	tunnelConnectorInfo = newGaugeVec(prometheus.GaugeOpts{
 		Name: tunnelConnectorInfoMetricName.String(),
 		Help: "Reports Cloudflare Tunnel connector details",
 	}, []string{"account", "tunnel_id", "client_id", "version", "arch", "origin_ip"})
 
-	tunnelConnectorActiveConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	tunnelConnectorActiveConnections = newGaugeVec(prometheus.GaugeOpts{
 		Name: tunnelConnectorActiveConnectionsMetricName.String(),
 		Help: "Reports number of active connections for a Cloudflare Tunnel connector",
 	}, []string{"account", "tunnel_id", "client_id"})
+
+	tunnelConnectorVersionSkew = newGaugeVec(prometheus.GaugeOpts{
+		Name: tunnelConnectorVersionSkewMetricName.String(),
+		Help: "Reports 1 if a Cloudflare Tunnel connector runs an older cloudflared version than the newest connector of the account, 0 otherwise",
+	}, []string{"account", "tunnel_id", "client_id", "version", "latest_version"})
+
+	tunnelConnectionInfo = newGaugeVec(prometheus.GaugeOpts{
+		Name: tunnelConnectionInfoMetricName.String(),
+		Help: "Reports Cloudflare Tunnel connection details",
+	}, []string{"account", "tunnel_id", "client_id", "connection_id", "colo", "origin_ip"})
+
+	tunnelConnectionAge = newGaugeVec(prometheus.GaugeOpts{
+		Name: tunnelConnectionAgeMetricName.String(),
+		Help: "Seconds since a Cloudflare Tunnel connection was opened",
+	}, []string{"account", "tunnel_id", "client_id", "connection_id", "colo"})
+
+	tunnelConnectionPendingReconnect = newGaugeVec(prometheus.GaugeOpts{
+		Name: tunnelConnectionPendingReconnectMetricName.String(),
+		Help: "Reports 1 if a Cloudflare Tunnel connection is pending reconnect, 0 otherwise",
+	}, []string{"account", "tunnel_id", "client_id", "connection_id", "colo"})
+
+	tunnelRequests = newCounterVec(prometheus.CounterOpts{
+		Name: tunnelRequestsMetricName.String(),
+		Help: "Number of requests served through a Cloudflare Tunnel",
+	}, []string{"account", "tunnel_id", "tunnel_name"})
+
+	tunnelBytes = newCounterVec(prometheus.CounterOpts{
+		Name: tunnelBytesMetricName.String(),
+		Help: "Number of bytes sent through a Cloudflare Tunnel by direction",
+	}, []string{"account", "tunnel_id", "tunnel_name", "direction"})
+
+	// Tunnel IDs exported per account by the previous scrape
+	tunnelsSeen   = make(map[string]map[string]struct{})
+	tunnelsSeenMu sync.Mutex
+
+	// Turnstile sitekeys exported per account by the previous scrape
+	turnstileWidgetsSeen   = make(map[string]map[string]struct{})
+	turnstileWidgetsSeenMu sync.Mutex
 )
 
 func buildAllMetricsSet() MetricsSet {
@@ -341,157 +1065,330 @@ func buildAllMetricsSet() MetricsSet {
 	allMetricsSet.Add(zoneColocationRequestsTotalMetricName)
 	allMetricsSet.Add(zoneFirewallEventsCountMetricName)
 	allMetricsSet.Add(zoneHealthCheckEventsOriginCountMetricName)
+	allMetricsSet.Add(zoneBotRequestsCountMetricName)
+	allMetricsSet.Add(zoneSecurityRuleHitsCountMetricName)
+	allMetricsSet.Add(zoneWAFOWASPEventsCountMetricName)
+	allMetricsSet.Add(zoneWAFAttackScoreRequestsCountMetricName)
+	allMetricsSet.Add(zoneRulesetInfoMetricName)
+	allMetricsSet.Add(zoneWAFOWASPScoreThresholdMetricName)
+	allMetricsSet.Add(zoneAPIShieldDiscoveredEndpointsMetricName)
+	allMetricsSet.Add(zoneAPIShieldSchemaViolationsCountMetricName)
+	allMetricsSet.Add(zoneAPIShieldSequenceMitigationCountMetricName)
+	allMetricsSet.Add(zonePageShieldScriptsMetricName)
+	allMetricsSet.Add(zonePageShieldConnectionsMetricName)
+	allMetricsSet.Add(zoneSpectrumBytesMetricName)
+	allMetricsSet.Add(zoneSpectrumPacketsMetricName)
+	allMetricsSet.Add(zoneSpectrumActiveConnectionsMetricName)
+	allMetricsSet.Add(magicTransitBitsMetricName)
+	allMetricsSet.Add(magicTransitPacketsMetricName)
+	allMetricsSet.Add(zoneDDoSMitigatedRequestsCountMetricName)
+	allMetricsSet.Add(zoneDDoSAttackInProgressMetricName)
+	allMetricsSet.Add(zoneDDoSAttacksMetricName)
+	allMetricsSet.Add(ddosAttacksMetricName)
+	allMetricsSet.Add(ddosPacketsMetricName)
+	allMetricsSet.Add(ddosBitsMetricName)
+	allMetricsSet.Add(ddosAttackInProgressMetricName)
+	allMetricsSet.Add(rumPageLoadsMetricName)
+	allMetricsSet.Add(rumLargestContentfulPaintMetricName)
+	allMetricsSet.Add(rumInteractionToNextPaintMetricName)
+	allMetricsSet.Add(rumCumulativeLayoutShiftMetricName)
+	allMetricsSet.Add(rumTimeToFirstByteMetricName)
+	allMetricsSet.Add(rumFirstContentfulPaintMetricName)
+	allMetricsSet.Add(zoneWaitingRoomTotalActiveUsersLimitMetricName)
+	allMetricsSet.Add(zoneWaitingRoomNewUsersPerMinuteLimitMetricName)
+	allMetricsSet.Add(zoneWaitingRoomStatusMetricName)
+	allMetricsSet.Add(zoneWaitingRoomQueuedUsersMetricName)
+	allMetricsSet.Add(zoneWaitingRoomActiveUsersMetricName)
+	allMetricsSet.Add(zoneWaitingRoomEstimatedWaitTimeMetricName)
+	allMetricsSet.Add(zoneWaitingRoomAdmittedUsersPerMinuteMetricName)
+	allMetricsSet.Add(zoneEmailRoutingMessagesMetricName)
+	allMetricsSet.Add(zoneEmailRoutingAuthResultsMetricName)
+	allMetricsSet.Add(zoneEmailRoutingRulesMetricName)
+	allMetricsSet.Add(emailRoutingDestinationAddressesMetricName)
+	allMetricsSet.Add(zoneArgoRequestsMetricName)
+	allMetricsSet.Add(zoneArgoOriginResponseDurationMetricName)
+	allMetricsSet.Add(zoneArgoSmartRoutedRatioMetricName)
+	allMetricsSet.Add(zoneTieredCacheRequestsMetricName)
+	allMetricsSet.Add(zoneTieredCacheUpperTierHitRatioMetricName)
+	allMetricsSet.Add(zoneTopPathRequestsMetricName)
+	allMetricsSet.Add(zoneTopPathBytesMetricName)
+	allMetricsSet.Add(zoneTopHostRequestsMetricName)
+	allMetricsSet.Add(zoneTopHostBytesMetricName)
+	allMetricsSet.Add(zoneTopUserAgentRequestsMetricName)
+	allMetricsSet.Add(zoneTopUserAgentBytesMetricName)
+	allMetricsSet.Add(zoneTopASNRequestsMetricName)
+	allMetricsSet.Add(zoneTopASNBytesMetricName)
+	allMetricsSet.Add(zoneTopClientIPRequestsMetricName)
+	allMetricsSet.Add(zoneTopClientIPBytesMetricName)
+	allMetricsSet.Add(zoneTopDeviceTypeRequestsMetricName)
+	allMetricsSet.Add(zoneTopDeviceTypeBytesMetricName)
+	allMetricsSet.Add(zoneTopASNThreatsMetricName)
+	allMetricsSet.Add(zoneSampleIntervalMetricName)
 	allMetricsSet.Add(workerRequestsMetricName)
 	allMetricsSet.Add(workerErrorsMetricName)
 	allMetricsSet.Add(workerCPUTimeMetricName)
 	allMetricsSet.Add(workerDurationMetricName)
+	allMetricsSet.Add(workerWallTimeMetricName)
+	allMetricsSet.Add(workerSubrequestsMetricName)
+	allMetricsSet.Add(workerScriptInfoMetricName)
+	allMetricsSet.Add(workerLastDeploymentMetricName)
+	allMetricsSet.Add(workerCustomDomainsMetricName)
+	allMetricsSet.Add(workerRoutesMetricName)
+	allMetricsSet.Add(workerCronTriggerInfoMetricName)
+	allMetricsSet.Add(workerCronExecutionsMetricName)
+	allMetricsSet.Add(aiGatewayRequestsMetricName)
+	allMetricsSet.Add(aiGatewayCachedRequestsMetricName)
+	allMetricsSet.Add(aiGatewayErrorsMetricName)
+	allMetricsSet.Add(aiGatewayTokensMetricName)
+	allMetricsSet.Add(aiGatewayCostMetricName)
+	allMetricsSet.Add(workersAIInferencesMetricName)
+	allMetricsSet.Add(workersAINeuronsMetricName)
 	allMetricsSet.Add(poolHealthStatusMetricName)
 	allMetricsSet.Add(poolOriginHealthStatusMetricName)
 	allMetricsSet.Add(poolRequestsTotalMetricName)
 	allMetricsSet.Add(logpushFailedJobsAccountMetricName)
 	allMetricsSet.Add(logpushFailedJobsZoneMetricName)
+	allMetricsSet.Add(logpushJobInfoMetricName)
+	allMetricsSet.Add(logpushJobLastCompleteMetricName)
+	allMetricsSet.Add(logpushJobLastErrorMetricName)
+	allMetricsSet.Add(logpushJobDeliveryLagMetricName)
+	allMetricsSet.Add(logpushUploadsMetricName)
+	allMetricsSet.Add(logpushBytesMetricName)
+	allMetricsSet.Add(logpushRecordsMetricName)
 	allMetricsSet.Add(r2StorageTotalMetricName)
+	allMetricsSet.Add(r2StorageMetricName)
+	allMetricsSet.Add(r2ObjectsMetricName)
+	allMetricsSet.Add(r2MetadataStorageMetricName)
 	allMetricsSet.Add(r2OperationMetricName)
+	allMetricsSet.Add(r2EgressMetricName)
+	allMetricsSet.Add(estimatedCostMetricName)
+	allMetricsSet.Add(imagesStoredMetricName)
+	allMetricsSet.Add(imagesStoredLimitMetricName)
+	allMetricsSet.Add(imagesVariantRequestsMetricName)
+	allMetricsSet.Add(imagesTransformationsMetricName)
+	allMetricsSet.Add(streamStorageMinutesMetricName)
+	allMetricsSet.Add(streamStorageMinutesLimitMetricName)
+	allMetricsSet.Add(streamVideosMetricName)
+	allMetricsSet.Add(streamMinutesDeliveredMetricName)
+	allMetricsSet.Add(streamVideoViewsMetricName)
+	allMetricsSet.Add(streamVideoMinutesViewedMetricName)
+	allMetricsSet.Add(turnstileWidgetInfoMetricName)
+	allMetricsSet.Add(turnstileChallengesMetricName)
+	allMetricsSet.Add(turnstileChallengeErrorsMetricName)
 	allMetricsSet.Add(tunnelInfoMetricName)
 	allMetricsSet.Add(tunnelHealthStatusMetricName)
 	allMetricsSet.Add(tunnelConnectorInfoMetricName)
 	allMetricsSet.Add(tunnelConnectorActiveConnectionsMetricName)
+	allMetricsSet.Add(tunnelConnectorVersionSkewMetricName)
+	allMetricsSet.Add(tunnelConnectionInfoMetricName)
+	allMetricsSet.Add(tunnelConnectionAgeMetricName)
+	allMetricsSet.Add(tunnelConnectionPendingReconnectMetricName)
+	allMetricsSet.Add(tunnelRequestsMetricName)
+	allMetricsSet.Add(tunnelBytesMetricName)
+	allMetricsSet.Add(droppedSeriesMetricName)
 	return allMetricsSet
 }
 
-func buildFilteredMetricsSet(metricsDenylist []string) (MetricsSet, error) {
+// buildFilteredMetricsSet returns the metrics not to export. With an
+// allowlist, every metric not selected by it is denied.
+func buildFilteredMetricsSet(metricsAllowlist, metricsDenylist []string) (MetricsSet, error) {
 	deniedMetricsSet := MetricsSet{}
 	allMetricsSet := buildAllMetricsSet()
 
-	for _, metric := range metricsDenylist {
-		if !allMetricsSet.Has(MetricName(metric)) {
-			return nil, fmt.Errorf("metric %s doesn't exists", metric)
+	if len(metricsAllowlist) > 0 {
+		allowedMetricsSet := MetricsSet{}
+		for _, selector := range metricsAllowlist {
+			metrics, err := selectMetrics(selector, allMetricsSet)
+			if err != nil {
+				return nil, err
+			}
+			for _, metric := range metrics {
+				allowedMetricsSet.Add(metric)
+			}
+		}
+		for metric := range allMetricsSet {
+			if !allowedMetricsSet.Has(metric) {
+				deniedMetricsSet.Add(metric)
+			}
+		}
+	}
+
+	for _, selector := range metricsDenylist {
+		metrics, err := selectMetrics(selector, allMetricsSet)
+		if err != nil {
+			return nil, err
+		}
+		for _, metric := range metrics {
+			deniedMetricsSet.Add(metric)
 		}
-		deniedMetricsSet.Add(MetricName(metric))
 	}
 	return deniedMetricsSet, nil
 }
 
+// metricCollectors returns the collector of every metric.
+func metricCollectors() map[MetricName]prometheus.Collector {
+	collectors := map[MetricName]prometheus.Collector{
+		zoneRequestTotalMetricName:                      zoneRequestTotal,
+		zoneRequestCachedMetricName:                     zoneRequestCached,
+		zoneRequestSSLEncryptedMetricName:               zoneRequestSSLEncrypted,
+		zoneRequestContentTypeMetricName:                zoneRequestContentType,
+		zoneRequestCountryMetricName:                    zoneRequestCountry,
+		zoneRequestHTTPStatusMetricName:                 zoneRequestHTTPStatus,
+		zoneRequestBrowserMapMetricName:                 zoneRequestBrowserMap,
+		zoneRequestOriginStatusCountryHostMetricName:    zoneRequestOriginStatusCountryHost,
+		zoneRequestStatusCountryHostMetricName:          zoneRequestStatusCountryHost,
+		zoneBandwidthTotalMetricName:                    zoneBandwidthTotal,
+		zoneBandwidthCachedMetricName:                   zoneBandwidthCached,
+		zoneBandwidthSSLEncryptedMetricName:             zoneBandwidthSSLEncrypted,
+		zoneBandwidthContentTypeMetricName:              zoneBandwidthContentType,
+		zoneBandwidthCountryMetricName:                  zoneBandwidthCountry,
+		zoneThreatsTotalMetricName:                      zoneThreatsTotal,
+		zoneThreatsCountryMetricName:                    zoneThreatsCountry,
+		zoneThreatsTypeMetricName:                       zoneThreatsType,
+		zonePageviewsTotalMetricName:                    zonePageviewsTotal,
+		zoneUniquesTotalMetricName:                      zoneUniquesTotal,
+		zoneColocationVisitsMetricName:                  zoneColocationVisits,
+		zoneColocationEdgeResponseBytesMetricName:       zoneColocationEdgeResponseBytes,
+		zoneColocationRequestsTotalMetricName:           zoneColocationRequestsTotal,
+		zoneFirewallEventsCountMetricName:               zoneFirewallEventsCount,
+		zoneHealthCheckEventsOriginCountMetricName:      zoneHealthCheckEventsOriginCount,
+		zoneBotRequestsCountMetricName:                  zoneBotRequestsCount,
+		zoneSecurityRuleHitsCountMetricName:             zoneSecurityRuleHitsCount,
+		zoneWAFOWASPEventsCountMetricName:               zoneWAFOWASPEventsCount,
+		zoneWAFAttackScoreRequestsCountMetricName:       zoneWAFAttackScoreRequestsCount,
+		zoneRulesetInfoMetricName:                       zoneRulesetInfo,
+		zoneWAFOWASPScoreThresholdMetricName:            zoneWAFOWASPScoreThreshold,
+		zoneAPIShieldDiscoveredEndpointsMetricName:      zoneAPIShieldDiscoveredEndpoints,
+		zoneAPIShieldSchemaViolationsCountMetricName:    zoneAPIShieldSchemaViolationsCount,
+		zoneAPIShieldSequenceMitigationCountMetricName:  zoneAPIShieldSequenceMitigationCount,
+		zonePageShieldScriptsMetricName:                 zonePageShieldScripts,
+		zonePageShieldConnectionsMetricName:             zonePageShieldConnections,
+		zoneSpectrumBytesMetricName:                     zoneSpectrumBytes,
+		zoneSpectrumPacketsMetricName:                   zoneSpectrumPackets,
+		zoneSpectrumActiveConnectionsMetricName:         zoneSpectrumActiveConnections,
+		magicTransitBitsMetricName:                      magicTransitBits,
+		magicTransitPacketsMetricName:                   magicTransitPackets,
+		zoneDDoSMitigatedRequestsCountMetricName:        zoneDDoSMitigatedRequestsCount,
+		zoneDDoSAttackInProgressMetricName:              zoneDDoSAttackInProgress,
+		zoneDDoSAttacksMetricName:                       zoneDDoSAttacks,
+		ddosAttacksMetricName:                           ddosAttacks,
+		ddosPacketsMetricName:                           ddosPackets,
+		ddosBitsMetricName:                              ddosBits,
+		ddosAttackInProgressMetricName:                  ddosAttackInProgress,
+		rumPageLoadsMetricName:                          rumPageLoads,
+		rumLargestContentfulPaintMetricName:             rumLargestContentfulPaint,
+		rumInteractionToNextPaintMetricName:             rumInteractionToNextPaint,
+		rumCumulativeLayoutShiftMetricName:              rumCumulativeLayoutShift,
+		rumTimeToFirstByteMetricName:                    rumTimeToFirstByte,
+		rumFirstContentfulPaintMetricName:               rumFirstContentfulPaint,
+		zoneWaitingRoomTotalActiveUsersLimitMetricName:  zoneWaitingRoomTotalActiveUsersLimit,
+		zoneWaitingRoomNewUsersPerMinuteLimitMetricName: zoneWaitingRoomNewUsersPerMinuteLimit,
+		zoneWaitingRoomStatusMetricName:                 zoneWaitingRoomStatus,
+		zoneWaitingRoomQueuedUsersMetricName:            zoneWaitingRoomQueuedUsers,
+		zoneWaitingRoomActiveUsersMetricName:            zoneWaitingRoomActiveUsers,
+		zoneWaitingRoomEstimatedWaitTimeMetricName:      zoneWaitingRoomEstimatedWaitTime,
+		zoneWaitingRoomAdmittedUsersPerMinuteMetricName: zoneWaitingRoomAdmittedUsersPerMinute,
+		zoneEmailRoutingMessagesMetricName:              zoneEmailRoutingMessages,
+		zoneEmailRoutingAuthResultsMetricName:           zoneEmailRoutingAuthResults,
+		zoneEmailRoutingRulesMetricName:                 zoneEmailRoutingRules,
+		emailRoutingDestinationAddressesMetricName:      emailRoutingDestinationAddresses,
+		zoneArgoRequestsMetricName:                      zoneArgoRequests,
+		zoneArgoOriginResponseDurationMetricName:        zoneArgoOriginResponseDuration,
+		zoneArgoSmartRoutedRatioMetricName:              zoneArgoSmartRoutedRatio,
+		zoneTieredCacheRequestsMetricName:               zoneTieredCacheRequests,
+		zoneTieredCacheUpperTierHitRatioMetricName:      zoneTieredCacheUpperTierHitRatio,
+		zoneTopPathRequestsMetricName:                   zoneTopPathRequests,
+		zoneTopPathBytesMetricName:                      zoneTopPathBytes,
+		zoneTopHostRequestsMetricName:                   zoneTopHostRequests,
+		zoneTopHostBytesMetricName:                      zoneTopHostBytes,
+		zoneTopUserAgentRequestsMetricName:              zoneTopUserAgentRequests,
+		zoneTopUserAgentBytesMetricName:                 zoneTopUserAgentBytes,
+		zoneTopASNRequestsMetricName:                    zoneTopASNRequests,
+		zoneTopASNBytesMetricName:                       zoneTopASNBytes,
+		zoneTopClientIPRequestsMetricName:               zoneTopClientIPRequests,
+		zoneTopClientIPBytesMetricName:                  zoneTopClientIPBytes,
+		zoneTopDeviceTypeRequestsMetricName:             zoneTopDeviceTypeRequests,
+		zoneTopDeviceTypeBytesMetricName:                zoneTopDeviceTypeBytes,
+		zoneTopASNThreatsMetricName:                     zoneTopASNThreats,
+		zoneSampleIntervalMetricName:                    zoneSampleInterval,
+		workerRequestsMetricName:                        workerRequests,
+		workerErrorsMetricName:                          workerErrors,
+		workerSubrequestsMetricName:                     workerSubrequests,
+		workerScriptInfoMetricName:                      workerScriptInfo,
+		workerLastDeploymentMetricName:                  workerLastDeployment,
+		workerCustomDomainsMetricName:                   workerCustomDomains,
+		workerRoutesMetricName:                          workerRoutes,
+		workerCronTriggerInfoMetricName:                 workerCronTriggerInfo,
+		workerCronExecutionsMetricName:                  workerCronExecutions,
+		aiGatewayRequestsMetricName:                     aiGatewayRequests,
+		aiGatewayCachedRequestsMetricName:               aiGatewayCachedRequests,
+		aiGatewayErrorsMetricName:                       aiGatewayErrors,
+		aiGatewayTokensMetricName:                       aiGatewayTokens,
+		aiGatewayCostMetricName:                         aiGatewayCost,
+		workersAIInferencesMetricName:                   workersAIInferences,
+		workersAINeuronsMetricName:                      workersAINeurons,
+		poolHealthStatusMetricName:                      poolHealthStatus,
+		poolOriginHealthStatusMetricName:                poolOriginHealthStatus,
+		poolRequestsTotalMetricName:                     poolRequestsTotal,
+		logpushFailedJobsAccountMetricName:              logpushFailedJobsAccount,
+		logpushFailedJobsZoneMetricName:                 logpushFailedJobsZone,
+		logpushJobInfoMetricName:                        logpushJobInfo,
+		logpushJobLastCompleteMetricName:                logpushJobLastComplete,
+		logpushJobLastErrorMetricName:                   logpushJobLastError,
+		logpushJobDeliveryLagMetricName:                 logpushJobDeliveryLag,
+		logpushUploadsMetricName:                        logpushUploads,
+		logpushBytesMetricName:                          logpushBytes,
+		logpushRecordsMetricName:                        logpushRecords,
+		r2StorageTotalMetricName:                        r2StorageTotal,
+		r2StorageMetricName:                             r2Storage,
+		r2ObjectsMetricName:                             r2Objects,
+		r2MetadataStorageMetricName:                     r2MetadataStorage,
+		r2OperationMetricName:                           r2Operation,
+		r2EgressMetricName:                              r2Egress,
+		estimatedCostMetricName:                         estimatedCost,
+		imagesStoredMetricName:                          imagesStored,
+		imagesStoredLimitMetricName:                     imagesStoredLimit,
+		imagesVariantRequestsMetricName:                 imagesVariantRequests,
+		imagesTransformationsMetricName:                 imagesTransformations,
+		streamStorageMinutesMetricName:                  streamStorageMinutes,
+		streamStorageMinutesLimitMetricName:             streamStorageMinutesLimit,
+		streamVideosMetricName:                          streamVideos,
+		streamMinutesDeliveredMetricName:                streamMinutesDelivered,
+		streamVideoViewsMetricName:                      streamVideoViews,
+		streamVideoMinutesViewedMetricName:              streamVideoMinutesViewed,
+		turnstileWidgetInfoMetricName:                   turnstileWidgetInfo,
+		turnstileChallengesMetricName:                   turnstileChallenges,
+		turnstileChallengeErrorsMetricName:              turnstileChallengeErrors,
+		tunnelInfoMetricName:                            tunnelInfo,
+		tunnelHealthStatusMetricName:                    tunnelHealthStatus,
+		tunnelConnectorInfoMetricName:                   tunnelConnectorInfo,
+		tunnelConnectorActiveConnectionsMetricName:      tunnelConnectorActiveConnections,
+		tunnelConnectorVersionSkewMetricName:            tunnelConnectorVersionSkew,
+		tunnelConnectionInfoMetricName:                  tunnelConnectionInfo,
+		tunnelConnectionAgeMetricName:                   tunnelConnectionAge,
+		tunnelConnectionPendingReconnectMetricName:      tunnelConnectionPendingReconnect,
+		tunnelRequestsMetricName:                        tunnelRequests,
+		tunnelBytesMetricName:                           tunnelBytes,
+		droppedSeriesMetricName:                         droppedSeries,
+	}
+	if useWorkerLatencySummary() {
+		collectors[workerCPUTimeMetricName] = workerCPUTimeSummary
+		collectors[workerDurationMetricName] = workerDurationSummary
+		collectors[workerWallTimeMetricName] = workerWallTimeSummary
+	} else {
+		collectors[workerCPUTimeMetricName] = workerCPUTime
+		collectors[workerDurationMetricName] = workerDuration
+		collectors[workerWallTimeMetricName] = workerWallTime
+	}
+	return collectors
+}
+
 func mustRegisterMetrics(deniedMetrics MetricsSet) {
-	if !deniedMetrics.Has(zoneRequestTotalMetricName) {
-		prometheus.MustRegister(zoneRequestTotal)
-	}
-	if !deniedMetrics.Has(zoneRequestCachedMetricName) {
-		prometheus.MustRegister(zoneRequestCached)
-	}
-	if !deniedMetrics.Has(zoneRequestSSLEncryptedMetricName) {
-		prometheus.MustRegister(zoneRequestSSLEncrypted)
-	}
-	if !deniedMetrics.Has(zoneRequestContentTypeMetricName) {
-		prometheus.MustRegister(zoneRequestContentType)
-	}
-	if !deniedMetrics.Has(zoneRequestCountryMetricName) {
-		prometheus.MustRegister(zoneRequestCountry)
-	}
-	if !deniedMetrics.Has(zoneRequestHTTPStatusMetricName) {
-		prometheus.MustRegister(zoneRequestHTTPStatus)
-	}
-	if !deniedMetrics.Has(zoneRequestBrowserMapMetricName) {
-		prometheus.MustRegister(zoneRequestBrowserMap)
-	}
-	if !deniedMetrics.Has(zoneRequestOriginStatusCountryHostMetricName) {
-		prometheus.MustRegister(zoneRequestOriginStatusCountryHost)
-	}
-	if !deniedMetrics.Has(zoneRequestStatusCountryHostMetricName) {
-		prometheus.MustRegister(zoneRequestStatusCountryHost)
-	}
-	if !deniedMetrics.Has(zoneBandwidthTotalMetricName) {
-		prometheus.MustRegister(zoneBandwidthTotal)
-	}
-	if !deniedMetrics.Has(zoneBandwidthCachedMetricName) {
-		prometheus.MustRegister(zoneBandwidthCached)
-	}
-	if !deniedMetrics.Has(zoneBandwidthSSLEncryptedMetricName) {
-		prometheus.MustRegister(zoneBandwidthSSLEncrypted)
-	}
-	if !deniedMetrics.Has(zoneBandwidthContentTypeMetricName) {
-		prometheus.MustRegister(zoneBandwidthContentType)
-	}
-	if !deniedMetrics.Has(zoneBandwidthCountryMetricName) {
-		prometheus.MustRegister(zoneBandwidthCountry)
-	}
-	if !deniedMetrics.Has(zoneThreatsTotalMetricName) {
-		prometheus.MustRegister(zoneThreatsTotal)
-	}
-	if !deniedMetrics.Has(zoneThreatsCountryMetricName) {
-		prometheus.MustRegister(zoneThreatsCountry)
-	}
-	if !deniedMetrics.Has(zoneThreatsTypeMetricName) {
-		prometheus.MustRegister(zoneThreatsType)
-	}
-	if !deniedMetrics.Has(zonePageviewsTotalMetricName) {
-		prometheus.MustRegister(zonePageviewsTotal)
-	}
-	if !deniedMetrics.Has(zoneUniquesTotalMetricName) {
-		prometheus.MustRegister(zoneUniquesTotal)
-	}
-	if !deniedMetrics.Has(zoneColocationVisitsMetricName) {
-		prometheus.MustRegister(zoneColocationVisits)
-	}
-	if !deniedMetrics.Has(zoneColocationEdgeResponseBytesMetricName) {
-		prometheus.MustRegister(zoneColocationEdgeResponseBytes)
-	}
-	if !deniedMetrics.Has(zoneColocationRequestsTotalMetricName) {
-		prometheus.MustRegister(zoneColocationRequestsTotal)
-	}
-	if !deniedMetrics.Has(zoneFirewallEventsCountMetricName) {
-		prometheus.MustRegister(zoneFirewallEventsCount)
-	}
-	if !deniedMetrics.Has(zoneHealthCheckEventsOriginCountMetricName) {
-		prometheus.MustRegister(zoneHealthCheckEventsOriginCount)
-	}
-	if !deniedMetrics.Has(workerRequestsMetricName) {
-		prometheus.MustRegister(workerRequests)
-	}
-	if !deniedMetrics.Has(workerErrorsMetricName) {
-		prometheus.MustRegister(workerErrors)
-	}
-	if !deniedMetrics.Has(workerCPUTimeMetricName) {
-		prometheus.MustRegister(workerCPUTime)
-	}
-	if !deniedMetrics.Has(workerDurationMetricName) {
-		prometheus.MustRegister(workerDuration)
-	}
-	if !deniedMetrics.Has(poolHealthStatusMetricName) {
-		prometheus.MustRegister(poolHealthStatus)
-	}
-	if !deniedMetrics.Has(poolOriginHealthStatusMetricName) {
-		prometheus.MustRegister(poolOriginHealthStatus)
-	}
-	if !deniedMetrics.Has(poolRequestsTotalMetricName) {
-		prometheus.MustRegister(poolRequestsTotal)
-	}
-	if !deniedMetrics.Has(logpushFailedJobsAccountMetricName) {
-		prometheus.MustRegister(logpushFailedJobsAccount)
-	}
-	if !deniedMetrics.Has(logpushFailedJobsZoneMetricName) {
-		prometheus.MustRegister(logpushFailedJobsZone)
-	}
-	if !deniedMetrics.Has(r2StorageTotalMetricName) {
-		prometheus.MustRegister(r2StorageTotal)
-	}
-	if !deniedMetrics.Has(r2StorageMetricName) {
-		prometheus.MustRegister(r2Storage)
-	}
-	if !deniedMetrics.Has(r2OperationMetricName) {
-		prometheus.MustRegister(r2Operation)
-	}
-	if !deniedMetrics.Has(tunnelInfoMetricName) {
-		prometheus.MustRegister(tunnelInfo)
-	}
-	if !deniedMetrics.Has(tunnelHealthStatusMetricName) {
-		prometheus.MustRegister(tunnelHealthStatus)
-	}
-	if !deniedMetrics.Has(tunnelConnectorInfoMetricName) {
-		prometheus.MustRegister(tunnelConnectorInfo)
-	}
-	if !deniedMetrics.Has(tunnelConnectorActiveConnectionsMetricName) {
-		prometheus.MustRegister(tunnelConnectorActiveConnections)
+	for name, collector := range metricCollectors() {
+		// droppedSeries is registered by the cardinality gatherer
+		if !deniedMetrics.Has(name) && name != droppedSeriesMetricName {
+			prometheus.MustRegister(collector)
+		}
 	}
 }
 
@@ -532,6 +1429,7 @@ func fetchLoadblancerPoolsHealth(account cfaccounts.Account, wg *sync.WaitGroup)
 func fetchWorkerAnalytics(account cfaccounts.Account, wg *sync.WaitGroup) {
 	defer wg.Done()
 
+	start := time.Now()
 	r, err := fetchWorkerTotals(account.ID)
 	if err != nil {
 		log.Error("failed to fetch worker analytics for account ", account.ID, ": ", err)
@@ -545,6 +1443,24 @@ func fetchWorkerAnalytics(account cfaccounts.Account, wg *sync.WaitGroup) {
 		for _, w := range a.WorkersInvocationsAdaptive {
 			workerRequests.With(prometheus.Labels{"script_name": w.Dimensions.ScriptName, "account": accountName, "status": w.Dimensions.Status}).Add(float64(w.Sum.Requests))
 			workerErrors.With(prometheus.Labels{"script_name": w.Dimensions.ScriptName, "account": accountName, "status": w.Dimensions.Status}).Add(float64(w.Sum.Errors))
+			workerSubrequests.With(prometheus.Labels{"script_name": w.Dimensions.ScriptName, "account": accountName, "status": w.Dimensions.Status}).Add(float64(w.Sum.Subrequests))
+			addCostUsage(costWorkersRequests, accountName, "", w.Dimensions.ScriptName, float64(w.Sum.Requests))
+			addCostUsage(costWorkersDurationGBs, accountName, "", w.Dimensions.ScriptName, w.Sum.Duration)
+
+			if useWorkerLatencySummary() {
+				labels := []string{w.Dimensions.ScriptName, accountName, w.Dimensions.Status}
+				workerCPUTimeSummary.observe(labels, w.Sum.Requests, w.Sum.CPUTimeUs, map[float64]float64{
+					0.5: float64(w.Quantiles.CPUTimeP50), 0.75: float64(w.Quantiles.CPUTimeP75), 0.99: float64(w.Quantiles.CPUTimeP99), 0.999: float64(w.Quantiles.CPUTimeP999),
+				})
+				workerDurationSummary.observe(labels, w.Sum.Requests, w.Sum.Duration, map[float64]float64{
+					0.5: float64(w.Quantiles.DurationP50), 0.75: float64(w.Quantiles.DurationP75), 0.99: float64(w.Quantiles.DurationP99), 0.999: float64(w.Quantiles.DurationP999),
+				})
+				workerWallTimeSummary.observe(labels, w.Sum.Requests, w.Sum.WallTime, map[float64]float64{
+					0.5: float64(w.Quantiles.WallTimeP50), 0.75: float64(w.Quantiles.WallTimeP75), 0.99: float64(w.Quantiles.WallTimeP99), 0.999: float64(w.Quantiles.WallTimeP999),
+				})
+				continue
+			}
+
 			workerCPUTime.With(prometheus.Labels{"script_name": w.Dimensions.ScriptName, "account": accountName, "status": w.Dimensions.Status, "quantile": "P50"}).Set(float64(w.Quantiles.CPUTimeP50))
 			workerCPUTime.With(prometheus.Labels{"script_name": w.Dimensions.ScriptName, "account": accountName, "status": w.Dimensions.Status, "quantile": "P75"}).Set(float64(w.Quantiles.CPUTimeP75))
 			workerCPUTime.With(prometheus.Labels{"script_name": w.Dimensions.ScriptName, "account": accountName, "status": w.Dimensions.Status, "quantile": "P99"}).Set(float64(w.Quantiles.CPUTimeP99))
@@ -553,55 +1469,668 @@ func fetchWorkerAnalytics(account cfaccounts.Account, wg *sync.WaitGroup) {
 			workerDuration.With(prometheus.Labels{"script_name": w.Dimensions.ScriptName, "account": accountName, "status": w.Dimensions.Status, "quantile": "P75"}).Set(float64(w.Quantiles.DurationP75))
 			workerDuration.With(prometheus.Labels{"script_name": w.Dimensions.ScriptName, "account": accountName, "status": w.Dimensions.Status, "quantile": "P99"}).Set(float64(w.Quantiles.DurationP99))
 			workerDuration.With(prometheus.Labels{"script_name": w.Dimensions.ScriptName, "account": accountName, "status": w.Dimensions.Status, "quantile": "P999"}).Set(float64(w.Quantiles.DurationP999))
+			workerWallTime.With(prometheus.Labels{"script_name": w.Dimensions.ScriptName, "account": accountName, "status": w.Dimensions.Status, "quantile": "P50"}).Set(float64(w.Quantiles.WallTimeP50))
+			workerWallTime.With(prometheus.Labels{"script_name": w.Dimensions.ScriptName, "account": accountName, "status": w.Dimensions.Status, "quantile": "P75"}).Set(float64(w.Quantiles.WallTimeP75))
+			workerWallTime.With(prometheus.Labels{"script_name": w.Dimensions.ScriptName, "account": accountName, "status": w.Dimensions.Status, "quantile": "P99"}).Set(float64(w.Quantiles.WallTimeP99))
+			workerWallTime.With(prometheus.Labels{"script_name": w.Dimensions.ScriptName, "account": accountName, "status": w.Dimensions.Status, "quantile": "P999"}).Set(float64(w.Quantiles.WallTimeP999))
 		}
 	}
+
+	if useWorkerLatencySummary() {
+		workerCPUTimeSummary.expire(accountName, start)
+		workerDurationSummary.expire(accountName, start)
+		workerWallTimeSummary.expire(accountName, start)
+	}
 }
 
-func fetchLogpushAnalyticsForAccount(account cfaccounts.Account, wg *sync.WaitGroup) {
-	defer wg.Done()
+const (
+	workerLatencyTypeGauge   = "gauge"
+	workerLatencyTypeSummary = "summary"
+)
 
-	if viper.GetBool("free_tier") {
-		return
+func useWorkerLatencySummary() bool {
+	return viper.GetString("worker_latency_type") == workerLatencyTypeSummary
+}
+
+// workerLatencySummary exports the quantiles computed by Cloudflare as a
+// summary. Sums and counts accumulate across scrapes like a client side
+// summary, while quantiles are those of the latest window. Series without
+// requests in the latest window are dropped, their sums and counts start
+// from zero when the script is invoked again.
+type workerLatencySummary struct {
+	desc *prometheus.Desc
+
+	mu     sync.Mutex
+	series map[string]*workerLatencySeries
+}
+
+type workerLatencySeries struct {
+	labels    []string
+	count     uint64
+	sum       float64
+	quantiles map[float64]float64
+	observed  time.Time
+}
+
+func newWorkerLatencySummary(name MetricName, help string) *workerLatencySummary {
+	labels := []string{"script_name", "account", "status"}
+	s := &workerLatencySummary{
+		desc:   prometheus.NewDesc(name.String(), help, labels, nil),
+		series: make(map[string]*workerLatencySeries),
 	}
+	metricDefinitions[s] = metricDefinition{metricType: metricTypeSummary, help: help, labels: labels}
+	return s
+}
 
-	r, err := fetchLogpushAccount(account.ID)
+func (s *workerLatencySummary) observe(labels []string, count uint64, sum float64, quantiles map[float64]float64) {
+	key := strings.Join(labels, "\xff")
 
-	if err != nil {
-		log.Error("failed to fetch logpush analytics for account ", account.ID, ": ", err)
-		return
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	series, exists := s.series[key]
+	if !exists {
+		series = &workerLatencySeries{labels: labels}
+		s.series[key] = series
 	}
+	series.count += count
+	series.sum += sum
+	series.quantiles = quantiles
+	series.observed = time.Now()
+}
 
-	for _, acc := range r.Viewer.Accounts {
-		for _, LogpushHealthAdaptiveGroup := range acc.LogpushHealthAdaptiveGroups {
-			logpushFailedJobsAccount.With(prometheus.Labels{"account": account.ID,
-				"destination": LogpushHealthAdaptiveGroup.Dimensions.DestinationType,
-				"job_id":      strconv.Itoa(LogpushHealthAdaptiveGroup.Dimensions.JobID),
-				"final":       strconv.Itoa(LogpushHealthAdaptiveGroup.Dimensions.Final)}).Add(float64(LogpushHealthAdaptiveGroup.Count))
+// expire drops the series of an account that were not observed since the
+// given time, e.g. of scripts without requests or deleted scripts.
+func (s *workerLatencySummary) expire(account string, since time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, series := range s.series {
+		if series.labels[1] == account && series.observed.Before(since) {
+			delete(s.series, key)
 		}
 	}
 }
 
-func fetchR2StorageForAccount(account cfaccounts.Account, wg *sync.WaitGroup) {
-	defer wg.Done()
+func (s *workerLatencySummary) Describe(ch chan<- *prometheus.Desc) {
+	ch <- s.desc
+}
 
-	r, err := fetchR2Account(account.ID)
+func (s *workerLatencySummary) Collect(ch chan<- prometheus.Metric) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	if err != nil {
-		return
+	for _, series := range s.series {
+		ch <- prometheus.MustNewConstSummary(s.desc, series.count, series.sum, series.quantiles, series.labels...)
 	}
-	for _, acc := range r.Viewer.Accounts {
+}
+
+// Inventories taking several REST calls per object are refreshed every
+// inventory_interval instead of every scrape to stay within the API rate
+// limit. Series of the last refresh are exported in between.
+var (
+	inventoryRefreshed   = map[string]time.Time{}
+	inventoryRefreshedMu sync.Mutex
+)
+
+func inventoryDue(key string, now time.Time) bool {
+	inventoryRefreshedMu.Lock()
+	defer inventoryRefreshedMu.Unlock()
+
+	interval := time.Duration(viper.GetInt("inventory_interval")) * time.Second
+	last, exists := inventoryRefreshed[key]
+	return !exists || now.Sub(last) >= interval
+}
+
+func markInventoryRefreshed(key string, now time.Time) {
+	inventoryRefreshedMu.Lock()
+	defer inventoryRefreshedMu.Unlock()
+	inventoryRefreshed[key] = now
+}
+
+func fetchWorkerInventoryForAccount(account cfaccounts.Account, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// Replace spaces with hyphens and convert to lowercase
+	accountName := strings.ToLower(strings.ReplaceAll(account.Name, " ", "-"))
+
+	key := "workers/" + account.ID
+	if now := time.Now(); inventoryDue(key, now) && refreshWorkerInventory(account, accountName) {
+		markInventoryRefreshed(key, now)
+	}
+
+	r, err := fetchWorkerCronTotals(account.ID)
+	if err != nil {
+		return
+	}
+	for _, a := range r.Viewer.Accounts {
+		for _, inv := range a.WorkersInvocationsScheduled {
+			workerCronExecutions.With(prometheus.Labels{"script_name": inv.ScriptName, "account": accountName, "cron": inv.Cron, "status": inv.Status}).Inc()
+		}
+	}
+}
+
+// refreshWorkerInventory updates the script, deployment, cron trigger and
+// custom domain series of an account and reports whether the scripts were
+// listed.
+func refreshWorkerInventory(account cfaccounts.Account, accountName string) bool {
+	scripts := fetchWorkerScripts(account.ID)
+	if scripts == nil {
+		return false
+	}
+
+	// Clear stale series for this account, scripts can be deleted
+	label := prometheus.Labels{"account": accountName}
+	workerScriptInfo.DeletePartialMatch(label)
+	workerLastDeployment.DeletePartialMatch(label)
+	workerCronTriggerInfo.DeletePartialMatch(label)
+
+	for _, script := range scripts {
+		versionID := ""
+		deployments := fetchWorkerDeployments(account.ID, script.ID)
+		if latest, deployedAt, ok := getLatestWorkerDeployment(deployments); ok {
+			workerLastDeployment.With(prometheus.Labels{"script_name": script.ID, "account": accountName}).Set(float64(deployedAt.Unix()))
+			versionID = getWorkerDeployedVersion(latest)
+		}
+
+		compatibilityDate := ""
+		if versionID != "" {
+			compatibilityDate = fetchWorkerCompatibilityDate(account.ID, script.ID, versionID)
+		}
+		workerScriptInfo.With(prometheus.Labels{
+			"script_name":        script.ID,
+			"account":            accountName,
+			"version_id":         versionID,
+			"compatibility_date": compatibilityDate,
+			"usage_model":        string(script.UsageModel),
+		}).Set(1)
+
+		for _, schedule := range fetchWorkerSchedules(account.ID, script.ID) {
+			workerCronTriggerInfo.With(prometheus.Labels{"script_name": script.ID, "account": accountName, "cron": schedule.Cron}).Set(1)
+		}
+	}
+
+	if domains := fetchWorkerDomains(account.ID); domains != nil {
+		workerCustomDomains.DeletePartialMatch(label)
+		for _, domain := range domains {
+			workerCustomDomains.With(prometheus.Labels{"script_name": domain.Service, "account": accountName}).Inc()
+		}
+	}
+	return true
+}
+
+// getLatestWorkerDeployment returns the most recent deployment and its time.
+func getLatestWorkerDeployment(deployments []cfworkers.ScriptDeploymentGetResponseDeployment) (cfworkers.ScriptDeploymentGetResponseDeployment, time.Time, bool) {
+	var latest cfworkers.ScriptDeploymentGetResponseDeployment
+	var latestAt time.Time
+	found := false
+	for _, d := range deployments {
+		createdOn, err := time.Parse(time.RFC3339, d.CreatedOn)
+		if err != nil {
+			continue
+		}
+		if !found || createdOn.After(latestAt) {
+			latest, latestAt, found = d, createdOn, true
+		}
+	}
+	return latest, latestAt, found
+}
+
+// getWorkerDeployedVersion returns the version serving the largest share of
+// traffic in a deployment, gradual deployments can split traffic.
+func getWorkerDeployedVersion(deployment cfworkers.ScriptDeploymentGetResponseDeployment) string {
+	versionID := ""
+	percentage := -1.0
+	for _, v := range deployment.Versions {
+		if v.Percentage > percentage {
+			versionID, percentage = v.VersionID, v.Percentage
+		}
+	}
+	return versionID
+}
+
+func fetchWorkerRoutesAnalytics(zones []cfzones.Zone, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// Routes are a paged listing per zone, the series are kept between
+	// refreshes every inventory_interval
+	for _, z := range zones {
+		key := "worker_routes/" + z.ID
+		now := time.Now()
+		if !inventoryDue(key, now) {
+			continue
+		}
+
+		routes := fetchWorkerRoutes(z.ID)
+		if routes == nil {
+			continue
+		}
+		markInventoryRefreshed(key, now)
+		name, account := findZoneAccountName(zones, z.ID)
+
+		// Clear stale series for this zone/account, routes can be removed
+		workerRoutes.DeletePartialMatch(prometheus.Labels{"zone": name, "account": account})
+
+		for _, route := range routes {
+			if route.Script == "" {
+				// Routes without a script disable workers on the pattern
+				continue
+			}
+			workerRoutes.With(prometheus.Labels{"script_name": route.Script, "zone": name, "account": account}).Inc()
+		}
+	}
+}
+
+func fetchAIAnalytics(account cfaccounts.Account, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	r, err := fetchAITotals(account.ID)
+	if err != nil {
+		log.Error("failed to fetch AI analytics for account ", account.ID, ": ", err)
+		return
+	}
+
+	// Replace spaces with hyphens and convert to lowercase
+	accountName := strings.ToLower(strings.ReplaceAll(account.Name, " ", "-"))
+
+	for _, a := range r.Viewer.Accounts {
+		for _, g := range a.AIGatewayRequestsAdaptiveGroups {
+			labels := prometheus.Labels{"account": accountName, "gateway": g.Dimensions.Gateway, "provider": g.Dimensions.Provider, "model": g.Dimensions.Model}
+			aiGatewayRequests.With(labels).Add(float64(g.Count))
+			aiGatewayCachedRequests.With(labels).Add(float64(g.Sum.CachedRequests))
+			aiGatewayErrors.With(labels).Add(float64(g.Sum.ErroredRequests))
+			aiGatewayCost.With(labels).Add(g.Sum.Cost)
+
+			tokensIn := prometheus.Labels{"account": accountName, "gateway": g.Dimensions.Gateway, "provider": g.Dimensions.Provider, "model": g.Dimensions.Model, "direction": "in"}
+			aiGatewayTokens.With(tokensIn).Add(float64(g.Sum.CachedTokensIn + g.Sum.UncachedTokensIn))
+			tokensOut := prometheus.Labels{"account": accountName, "gateway": g.Dimensions.Gateway, "provider": g.Dimensions.Provider, "model": g.Dimensions.Model, "direction": "out"}
+			aiGatewayTokens.With(tokensOut).Add(float64(g.Sum.CachedTokensOut + g.Sum.UncachedTokensOut))
+		}
+		for _, g := range a.AIInferenceAdaptiveGroups {
+			workersAIInferences.With(prometheus.Labels{"account": accountName, "model": g.Dimensions.ModelID}).Add(float64(g.Count))
+			workersAINeurons.With(prometheus.Labels{"account": accountName, "model": g.Dimensions.ModelID}).Add(g.Sum.TotalNeurons)
+		}
+	}
+}
+
+func fetchLogpushAnalyticsForAccount(account cfaccounts.Account, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	if viper.GetBool("free_tier") {
+		return
+	}
+
+	// Replace spaces with hyphens and convert to lowercase, as for zone metrics
+	accountName := strings.ToLower(strings.ReplaceAll(account.Name, " ", "-"))
+	jobs := cachedLogpushJobs("logpush_jobs/"+account.ID, func() []cflogpush.LogpushJob {
+		return fetchLogpushJobs(account.ID, "")
+	})
+	jobNames := addLogpushJobs(jobs, "", accountName)
+
+	r, err := fetchLogpushAccount(account.ID)
+
+	if err != nil {
+		log.Error("failed to fetch logpush analytics for account ", account.ID, ": ", err)
+		return
+	}
+
+	for _, acc := range r.Viewer.Accounts {
+		addLogpushHealthGroups(&acc, jobNames, "", accountName)
+		for _, LogpushHealthAdaptiveGroup := range acc.LogpushHealthAdaptiveGroups {
+			if LogpushHealthAdaptiveGroup.Dimensions.Status == http.StatusOK {
+				continue
+			}
+			logpushFailedJobsAccount.With(prometheus.Labels{"account": accountName,
+				"job":         jobNames[LogpushHealthAdaptiveGroup.Dimensions.JobID],
+				"destination": LogpushHealthAdaptiveGroup.Dimensions.DestinationType,
+				"job_id":      strconv.Itoa(LogpushHealthAdaptiveGroup.Dimensions.JobID),
+				"final":       strconv.Itoa(LogpushHealthAdaptiveGroup.Dimensions.Final)}).Add(float64(LogpushHealthAdaptiveGroup.Count))
+		}
+	}
+}
+
+var (
+	// Logpush jobs by inventory key, refreshed every inventory_interval
+	logpushJobs   = map[string][]cflogpush.LogpushJob{}
+	logpushJobsMu sync.Mutex
+)
+
+// cachedLogpushJobs returns the logpush jobs listed at the last refresh and
+// lists them again once inventory_interval has passed. A nil slice means the
+// jobs have never been listed successfully.
+func cachedLogpushJobs(key string, fetch func() []cflogpush.LogpushJob) []cflogpush.LogpushJob {
+	logpushJobsMu.Lock()
+	cached := logpushJobs[key]
+	logpushJobsMu.Unlock()
+
+	now := time.Now()
+	if !inventoryDue(key, now) {
+		return cached
+	}
+
+	jobs := fetch()
+	if jobs == nil {
+		return cached
+	}
+	logpushJobsMu.Lock()
+	logpushJobs[key] = jobs
+	logpushJobsMu.Unlock()
+	markInventoryRefreshed(key, now)
+	return jobs
+}
+
+// addLogpushJobs exports the inventory of logpush jobs and returns the job
+// names by job ID. A nil jobs slice means the jobs could not be listed.
+func addLogpushJobs(jobs []cflogpush.LogpushJob, zone string, account string) map[int]string {
+	jobNames := make(map[int]string, len(jobs))
+	if jobs == nil {
+		return jobNames
+	}
+
+	// Clear stale series for this zone/account, jobs can be removed
+	label := prometheus.Labels{"zone": zone, "account": account}
+	logpushJobInfo.DeletePartialMatch(label)
+	logpushJobLastComplete.DeletePartialMatch(label)
+	logpushJobLastError.DeletePartialMatch(label)
+	logpushJobDeliveryLag.DeletePartialMatch(label)
+
+	for _, job := range jobs {
+		jobNames[int(job.ID)] = job.Name
+		labels := prometheus.Labels{
+			"zone":    zone,
+			"account": account,
+			"job":     job.Name,
+			"job_id":  strconv.FormatInt(job.ID, 10),
+		}
+		logpushJobInfo.With(prometheus.Labels{
+			"zone":        zone,
+			"account":     account,
+			"job":         job.Name,
+			"job_id":      strconv.FormatInt(job.ID, 10),
+			"dataset":     job.Dataset,
+			"destination": getLogpushDestinationType(job.DestinationConf),
+			"enabled":     strconv.FormatBool(job.Enabled),
+		}).Set(1)
+
+		if !job.LastComplete.IsZero() {
+			logpushJobLastComplete.With(labels).Set(float64(job.LastComplete.Unix()))
+			if job.Enabled {
+				logpushJobDeliveryLag.With(labels).Set(time.Since(job.LastComplete).Seconds())
+			}
+		}
+		if !job.LastError.IsZero() {
+			logpushJobLastError.With(labels).Set(float64(job.LastError.Unix()))
+		}
+	}
+
+	return jobNames
+}
+
+func addLogpushHealthGroups(r *logpushResponse, jobNames map[int]string, zone string, account string) {
+	for _, g := range r.LogpushHealthAdaptiveGroups {
+		labels := prometheus.Labels{
+			"zone":        zone,
+			"account":     account,
+			"job":         jobNames[g.Dimensions.JobID],
+			"job_id":      strconv.Itoa(g.Dimensions.JobID),
+			"destination": g.Dimensions.DestinationType,
+		}
+		if g.Dimensions.Status == http.StatusOK {
+			logpushBytes.With(labels).Add(float64(g.Sum.Bytes))
+			logpushRecords.With(labels).Add(float64(g.Sum.Records))
+		}
+		labels["status"] = strconv.Itoa(g.Dimensions.Status)
+		logpushUploads.With(labels).Add(float64(g.Count))
+	}
+}
+
+// getLogpushDestinationType returns the scheme of a logpush destination,
+// e.g. s3 or https, without exposing credentials from the destination.
+func getLogpushDestinationType(destinationConf string) string {
+	scheme, _, found := strings.Cut(destinationConf, ":")
+	if !found {
+		return ""
+	}
+	return scheme
+}
+
+func fetchR2StorageForAccount(account cfaccounts.Account, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	r, err := fetchR2Account(account.ID)
+
+	if err != nil {
+		return
+	}
+
+	// Clear stale series for this account, buckets can be removed
+	label := prometheus.Labels{"account": account.Name}
+	r2Storage.DeletePartialMatch(label)
+	r2Objects.DeletePartialMatch(label)
+	r2MetadataStorage.DeletePartialMatch(label)
+
+	for _, acc := range r.Viewer.Accounts {
 		var totalStorage uint64
+		storageByClass := make(map[string]uint64)
 		for _, bucket := range acc.R2StorageGroups {
 			totalStorage += bucket.Max.PayloadSize
-			r2Storage.With(prometheus.Labels{"account": account.Name, "bucket": bucket.Dimensions.BucketName}).Set(float64(bucket.Max.PayloadSize))
+			storageByClass[bucket.Dimensions.StorageClass] += bucket.Max.PayloadSize + bucket.Max.MetadataSize
+			labels := prometheus.Labels{"account": account.Name, "bucket": bucket.Dimensions.BucketName, "storage_class": bucket.Dimensions.StorageClass}
+			r2Storage.With(labels).Set(float64(bucket.Max.PayloadSize))
+			r2Objects.With(labels).Set(float64(bucket.Max.ObjectCount))
+			r2MetadataStorage.With(labels).Set(float64(bucket.Max.MetadataSize))
 		}
 		for _, operation := range acc.R2StorageOperations {
-			r2Operation.With(prometheus.Labels{"account": account.Name, "bucket": operation.Dimensions.BucketName, "operation": operation.Dimensions.Action}).Set(float64(operation.Sum.Requests))
+			r2Operation.With(prometheus.Labels{
+				"account":       account.Name,
+				"bucket":        operation.Dimensions.BucketName,
+				"storage_class": operation.Dimensions.StorageClass,
+				"operation":     operation.Dimensions.Action,
+				"class":         getR2OperationClass(operation.Dimensions.Action),
+				"status":        strconv.Itoa(operation.Dimensions.ResponseStatusCode),
+			}).Add(float64(operation.Sum.Requests))
+			// Only reads serve data, writes also report response bytes
+			if isR2ReadOperation(operation.Dimensions.Action) {
+				r2Egress.With(prometheus.Labels{
+					"account":       account.Name,
+					"bucket":        operation.Dimensions.BucketName,
+					"storage_class": operation.Dimensions.StorageClass,
+				}).Add(float64(operation.Sum.ResponseBytes))
+			}
+
+			switch getR2OperationClass(operation.Dimensions.Action) {
+			case "A":
+				addCostUsage(getR2CostDimension(costR2ClassAOperations, operation.Dimensions.StorageClass), account.Name, "", "", float64(operation.Sum.Requests))
+			case "B":
+				addCostUsage(getR2CostDimension(costR2ClassBOperations, operation.Dimensions.StorageClass), account.Name, "", "", float64(operation.Sum.Requests))
+			}
+		}
+		for storageClass, size := range storageByClass {
+			setCostLevelUsage(getR2CostDimension(costR2StorageGBMonth, storageClass), account.Name, "", "", float64(size)/bytesPerGB)
 		}
 		r2StorageTotal.With(prometheus.Labels{"account": account.Name}).Set(float64(totalStorage))
 	}
 }
 
+// isR2ReadOperation reports whether an R2 action returns object data
+func isR2ReadOperation(action string) bool {
+	return action == "GetObject"
+}
+
+// getR2OperationClass maps an R2 action to its billing class as documented
+// at https://developers.cloudflare.com/r2/pricing/#class-a-operations
+func getR2OperationClass(action string) string {
+	switch action {
+	case "ListBuckets", "PutBucket", "ListObjects", "PutObject", "CopyObject",
+		"CompleteMultipartUpload", "CreateMultipartUpload", "LifecycleStorageTierTransition",
+		"ListMultipartUploads", "UploadPart", "UploadPartCopy", "ListParts",
+		"PutBucketEncryption", "PutBucketCors", "PutBucketLifecycleConfiguration":
+		return "A"
+	case "HeadBucket", "HeadObject", "GetObject", "UsageSummary",
+		"GetBucketEncryption", "GetBucketLocation", "GetBucketCors",
+		"GetBucketLifecycleConfiguration":
+		return "B"
+	case "DeleteObject", "DeleteBucket", "AbortMultipartUpload":
+		return "free"
+	}
+	return "unknown"
+}
+
+func fetchImagesUsageForAccount(account cfaccounts.Account, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	stats, err := fetchImagesStats(account.ID)
+	if err != nil {
+		log.Debugf("failed to fetch images stats for account %s: %v", account.ID, err)
+		return
+	}
+	imagesStored.With(prometheus.Labels{"account": account.Name}).Set(stats.Count.Current)
+	imagesStoredLimit.With(prometheus.Labels{"account": account.Name}).Set(stats.Count.Allowed)
+	setCostLevelUsage(costImagesStored, account.Name, "", "", stats.Count.Current)
+
+	r, err := fetchImagesAccount(account.ID)
+	if err != nil {
+		return
+	}
+
+	// Clear stale series for this account, the values are reset every day
+	label := prometheus.Labels{"account": account.Name}
+	imagesVariantRequests.DeletePartialMatch(label)
+	imagesTransformations.DeletePartialMatch(label)
+
+	now, _ := GetTimeRange()
+	date := now.Format("2006-01-02")
+	for _, acc := range r.Viewer.Accounts {
+		var delivered, transformations float64
+		for _, g := range acc.ImagesRequestsAdaptiveGroups {
+			imagesVariantRequests.With(prometheus.Labels{"account": account.Name, "variant": g.Dimensions.Variant}).Add(float64(g.Sum.Requests))
+			delivered += float64(g.Sum.Requests)
+		}
+		for _, g := range acc.ImagesUniqueTransformations {
+			imagesTransformations.With(prometheus.Labels{"account": account.Name, "type": g.Dimensions.TransformationType}).Add(float64(g.Count))
+			transformations += float64(g.Count)
+		}
+		setCostDailyUsage(costImagesDelivered, account.Name, "", "", date, delivered)
+		setCostDailyUsage(costImagesTransformations, account.Name, "", "", date, transformations)
+	}
+}
+
+func fetchStreamUsageForAccount(account cfaccounts.Account, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	usage, err := fetchStreamStorageUsage(account.ID)
+	if err != nil {
+		log.Debugf("failed to fetch stream storage usage for account %s: %v", account.ID, err)
+		return
+	}
+	streamStorageMinutes.With(prometheus.Labels{"account": account.Name}).Set(float64(usage.TotalStorageMinutes))
+	streamStorageMinutesLimit.With(prometheus.Labels{"account": account.Name}).Set(float64(usage.TotalStorageMinutesLimit))
+	streamVideos.With(prometheus.Labels{"account": account.Name}).Set(float64(usage.VideoCount))
+	setCostLevelUsage(costStreamMinutesStored, account.Name, "", "", float64(usage.TotalStorageMinutes))
+
+	r, err := fetchStreamAccount(account.ID, viper.GetInt("stream_top_videos"))
+	if err != nil {
+		return
+	}
+
+	// Clear stale series for this account, videos can drop out of the top N
+	label := prometheus.Labels{"account": account.Name}
+	streamVideoViews.DeletePartialMatch(label)
+	streamVideoMinutesViewed.DeletePartialMatch(label)
+
+	for _, acc := range r.Viewer.Accounts {
+		var minutesDelivered float64
+		for _, g := range acc.StreamMinutesViewed {
+			minutesDelivered += g.Sum.MinutesViewed
+		}
+		streamMinutesDelivered.With(label).Set(minutesDelivered)
+		now, _ := GetTimeRange()
+		setCostDailyUsage(costStreamMinutesDelivered, account.Name, "", "", now.Format("2006-01-02"), minutesDelivered)
+
+		for _, g := range acc.StreamTopVideos {
+			streamVideoViews.With(prometheus.Labels{"account": account.Name, "video_id": g.Dimensions.UID}).Set(float64(g.Count))
+			streamVideoMinutesViewed.With(prometheus.Labels{"account": account.Name, "video_id": g.Dimensions.UID}).Set(g.Sum.MinutesViewed)
+		}
+	}
+}
+
+func fetchTurnstileAnalyticsForAccount(account cfaccounts.Account, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	widgets := fetchTurnstileWidgets(account.ID)
+	if widgets == nil {
+		return
+	}
+
+	// Clear stale series for this account, widgets can be removed
+	turnstileWidgetInfo.DeletePartialMatch(prometheus.Labels{"account": account.Name})
+	deleteStaleTurnstileWidgets(account.Name, widgets)
+
+	widgetNames := make(map[string]string, len(widgets))
+	for _, w := range widgets {
+		widgetNames[w.Sitekey] = w.Name
+		turnstileWidgetInfo.With(prometheus.Labels{
+			"account": account.Name,
+			"sitekey": w.Sitekey,
+			"widget":  w.Name,
+			"mode":    string(w.Mode),
+		}).Set(1)
+	}
+
+	r, err := fetchTurnstileAccount(account.ID)
+	if err != nil {
+		return
+	}
+
+	for _, acc := range r.Viewer.Accounts {
+		for _, g := range acc.TurnstileAdaptiveGroups {
+			if _, exists := widgetNames[g.Dimensions.SiteKey]; !exists {
+				// Deleted widgets are cleaned up and must not come back
+				continue
+			}
+			turnstileChallenges.With(prometheus.Labels{
+				"account": account.Name,
+				"sitekey": g.Dimensions.SiteKey,
+				"widget":  widgetNames[g.Dimensions.SiteKey],
+				"action":  g.Dimensions.Action,
+				"event":   g.Dimensions.EventType,
+			}).Add(float64(g.Count))
+
+			if g.Dimensions.ErrorCode == "" {
+				continue
+			}
+			turnstileChallengeErrors.With(prometheus.Labels{
+				"account":    account.Name,
+				"sitekey":    g.Dimensions.SiteKey,
+				"widget":     widgetNames[g.Dimensions.SiteKey],
+				"error_code": g.Dimensions.ErrorCode,
+			}).Add(float64(g.Count))
+		}
+	}
+}
+
+// deleteStaleTurnstileWidgets removes the challenge series of widgets that
+// were exported by the previous scrape of the account but no longer exist.
+func deleteStaleTurnstileWidgets(account string, widgets []cfturnstile.WidgetListResponse) {
+	current := make(map[string]struct{}, len(widgets))
+	for _, w := range widgets {
+		current[w.Sitekey] = struct{}{}
+	}
+
+	turnstileWidgetsSeenMu.Lock()
+	defer turnstileWidgetsSeenMu.Unlock()
+
+	for sitekey := range turnstileWidgetsSeen[account] {
+		if _, exists := current[sitekey]; exists {
+			continue
+		}
+		label := prometheus.Labels{"account": account, "sitekey": sitekey}
+		turnstileChallenges.DeletePartialMatch(label)
+		turnstileChallengeErrors.DeletePartialMatch(label)
+	}
+	turnstileWidgetsSeen[account] = current
+}
+
 func fetchLogpushAnalyticsForZone(zones []cfzones.Zone, wg *sync.WaitGroup) {
 	defer wg.Done()
 
@@ -622,10 +2151,24 @@ func fetchLogpushAnalyticsForZone(zones []cfzones.Zone, wg *sync.WaitGroup) {
 	}
 
 	for _, zone := range r.Viewer.Zones {
+		name, account := findZoneAccountName(zones, zone.ZoneTag)
+		zoneTag := zone.ZoneTag
+		jobs := cachedLogpushJobs("logpush_jobs/"+zoneTag, func() []cflogpush.LogpushJob {
+			return fetchLogpushJobs("", zoneTag)
+		})
+		jobNames := addLogpushJobs(jobs, name, account)
+		addLogpushHealthGroups(&zone, jobNames, name, account)
+
 		for _, LogpushHealthAdaptiveGroup := range zone.LogpushHealthAdaptiveGroups {
-			logpushFailedJobsZone.With(prometheus.Labels{"destination": LogpushHealthAdaptiveGroup.Dimensions.DestinationType,
-				"job_id": strconv.Itoa(LogpushHealthAdaptiveGroup.Dimensions.JobID),
-				"final":  strconv.Itoa(LogpushHealthAdaptiveGroup.Dimensions.Final)}).Add(float64(LogpushHealthAdaptiveGroup.Count))
+			if LogpushHealthAdaptiveGroup.Dimensions.Status == http.StatusOK {
+				continue
+			}
+			logpushFailedJobsZone.With(prometheus.Labels{"zone": name,
+				"account":     account,
+				"job":         jobNames[LogpushHealthAdaptiveGroup.Dimensions.JobID],
+				"destination": LogpushHealthAdaptiveGroup.Dimensions.DestinationType,
+				"job_id":      strconv.Itoa(LogpushHealthAdaptiveGroup.Dimensions.JobID),
+				"final":       strconv.Itoa(LogpushHealthAdaptiveGroup.Dimensions.Final)}).Add(float64(LogpushHealthAdaptiveGroup.Count))
 		}
 	}
 }
@@ -651,11 +2194,16 @@ func fetchZoneColocationAnalytics(zones []cfzones.Zone, wg *sync.WaitGroup) {
 	for _, z := range r.Viewer.Zones {
 		cg := z.ColoGroups
 		name, account := findZoneAccountName(zones, z.ZoneTag)
+		var sampleInterval sampleIntervalAverage
 		for _, c := range cg {
-			zoneColocationVisits.With(prometheus.Labels{"zone": name, "account": account, "colocation": c.Dimensions.ColoCode, "host": c.Dimensions.Host}).Add(float64(c.Sum.Visits))
-			zoneColocationEdgeResponseBytes.With(prometheus.Labels{"zone": name, "account": account, "colocation": c.Dimensions.ColoCode, "host": c.Dimensions.Host}).Add(float64(c.Sum.EdgeResponseBytes))
-			zoneColocationRequestsTotal.With(prometheus.Labels{"zone": name, "account": account, "colocation": c.Dimensions.ColoCode, "host": c.Dimensions.Host}).Add(float64(c.Count))
+			si := c.Avg.SampleInterval
+			labels := sampledLabels(prometheus.Labels{"zone": name, "account": account, "colocation": c.Dimensions.ColoCode, "host": c.Dimensions.Host}, si)
+			zoneColocationVisits.With(labels).Add(sampled(c.Sum.Visits, si))
+			zoneColocationEdgeResponseBytes.With(labels).Add(sampled(c.Sum.EdgeResponseBytes, si))
+			zoneColocationRequestsTotal.With(labels).Add(sampled(c.Count, si))
+			sampleInterval.add(c.Count, si)
 		}
+		sampleInterval.set(name, account, sampledDatasetColocation)
 	}
 }
 
@@ -726,12 +2274,12 @@ func addHTTPGroups(z *zoneResp, name string, account string) {
 	}
 
 	for _, country := range zt.Sum.Country {
-		c := countries.ByName(country.ClientCountryName)
-		region := c.Info().Region.Info().Name
+		c := normalizeCountry(country.ClientCountryName)
+		region := legacyRegion(country.ClientCountryName)
 
-		zoneRequestCountry.With(prometheus.Labels{"zone": name, "account": account, "country": country.ClientCountryName, "region": region}).Add(float64(country.Requests))
-		zoneBandwidthCountry.With(prometheus.Labels{"zone": name, "account": account, "country": country.ClientCountryName, "region": region}).Add(float64(country.Bytes))
-		zoneThreatsCountry.With(prometheus.Labels{"zone": name, "account": account, "country": country.ClientCountryName, "region": region}).Add(float64(country.Threats))
+		zoneRequestCountry.With(c.apply(prometheus.Labels{"zone": name, "account": account, "region": region})).Add(float64(country.Requests))
+		zoneBandwidthCountry.With(c.apply(prometheus.Labels{"zone": name, "account": account, "region": region})).Add(float64(country.Bytes))
+		zoneThreatsCountry.With(c.apply(prometheus.Labels{"zone": name, "account": account, "region": region})).Add(float64(country.Threats))
 	}
 
 	for _, status := range zt.Sum.ResponseStatus {
@@ -769,8 +2317,10 @@ func addFirewallGroups(z *zoneResp, name string, account string) {
 	zoneFirewallEventsCount.DeletePartialMatch(label)
 
 	rulesMap := fetchFirewallRules(z.ZoneTag)
+	var sampleInterval sampleIntervalAverage
 	for _, g := range z.FirewallEventsAdaptiveGroups {
-		zoneFirewallEventsCount.With(
+		sampleInterval.add(g.Count, g.Avg.SampleInterval)
+		zoneFirewallEventsCount.With(sampledLabels(normalizeCountry(g.Dimensions.ClientCountryName).apply(
 			prometheus.Labels{
 				"zone":    name,
 				"account": account,
@@ -778,9 +2328,9 @@ func addFirewallGroups(z *zoneResp, name string, account string) {
 				"source":  g.Dimensions.Source,
 				"rule":    normalizeRuleName(rulesMap[g.Dimensions.RuleID]),
 				"host":    g.Dimensions.ClientRequestHTTPHost,
-				"country": g.Dimensions.ClientCountryName,
-			}).Add(float64(g.Count))
+			}), g.Avg.SampleInterval)).Add(sampled(g.Count, g.Avg.SampleInterval))
 	}
+	sampleInterval.set(name, account, sampledDatasetFirewallEvents)
 }
 
 func normalizeRuleName(initialText string) string {
@@ -820,33 +2370,36 @@ func addHTTPAdaptiveGroups(z *zoneResp, name string, account string) {
 	zoneRequestOriginStatusCountryHost.DeletePartialMatch(label)
 	zoneRequestStatusCountryHost.DeletePartialMatch(label)
 
+	var originSampleInterval, edgeSampleInterval sampleIntervalAverage
 	for _, g := range z.HTTPRequestsAdaptiveGroups {
-		zoneRequestOriginStatusCountryHost.With(
+		originSampleInterval.add(g.Count, g.Avg.SampleInterval)
+		zoneRequestOriginStatusCountryHost.With(sampledLabels(normalizeCountry(g.Dimensions.ClientCountryName).apply(
 			prometheus.Labels{
 				"zone":    name,
 				"account": account,
 				"status":  strconv.Itoa(int(g.Dimensions.OriginResponseStatus)),
-				"country": g.Dimensions.ClientCountryName,
 				"host":    g.Dimensions.ClientRequestHTTPHost,
-			}).Add(float64(g.Count))
+			}), g.Avg.SampleInterval)).Add(sampled(g.Count, g.Avg.SampleInterval))
 	}
+	originSampleInterval.set(name, account, sampledDatasetHTTPOrigin)
 
 	for _, g := range z.HTTPRequestsEdgeCountryHost {
-		zoneRequestStatusCountryHost.With(
+		edgeSampleInterval.add(g.Count, g.Avg.SampleInterval)
+		zoneRequestStatusCountryHost.With(sampledLabels(normalizeCountry(g.Dimensions.ClientCountryName).apply(
 			prometheus.Labels{
 				"zone":    name,
 				"account": account,
 				"status":  strconv.Itoa(int(g.Dimensions.EdgeResponseStatus)),
-				"country": g.Dimensions.ClientCountryName,
 				"host":    g.Dimensions.ClientRequestHTTPHost,
-			}).Add(float64(g.Count))
+			}), g.Avg.SampleInterval)).Add(sampled(g.Count, g.Avg.SampleInterval))
 	}
+	edgeSampleInterval.set(name, account, sampledDatasetHTTPEdge)
 }
 
-func fetchLoadBalancerAnalytics(zones []cfzones.Zone, wg *sync.WaitGroup) {
+func fetchBotManagementAnalytics(zones []cfzones.Zone, wg *sync.WaitGroup) {
 	defer wg.Done()
 
-	// None of the below referenced metrics are available in the free tier
+	// Bot Management is an enterprise add-on
 	if viper.GetBool("free_tier") {
 		return
 	}
@@ -856,39 +2409,1042 @@ func fetchLoadBalancerAnalytics(zones []cfzones.Zone, wg *sync.WaitGroup) {
 		return
 	}
 
-	l, err := fetchLoadBalancerTotals(zoneIDs)
+	r, err := fetchBotTotals(zoneIDs)
 	if err != nil {
-		log.Error("failed to fetch load balancer analytics: ", err)
+		log.Error("failed to fetch bot management analytics: ", err)
 		return
 	}
-	for _, lb := range l.Viewer.Zones {
-		name, account := findZoneAccountName(zones, lb.ZoneTag)
-		lb := lb
-		addLoadBalancingRequestsAdaptive(&lb, name, account)
-		addLoadBalancingRequestsAdaptiveGroups(&lb, name, account)
+
+	for _, z := range r.Viewer.Zones {
+		name, account := findZoneAccountName(zones, z.ZoneTag)
+
+		// Clear stale series for this zone/account
+		zoneBotRequestsCount.DeletePartialMatch(prometheus.Labels{"zone": name, "account": account})
+
+		var sampleInterval sampleIntervalAverage
+		for _, g := range z.BotGroups {
+			sampleInterval.add(g.Count, g.Avg.SampleInterval)
+			zoneBotRequestsCount.With(sampledLabels(
+				prometheus.Labels{
+					"zone":                  name,
+					"account":               account,
+					"host":                  g.Dimensions.Host,
+					"bot_score_bucket":      getBotScoreBucket(g.Dimensions.BotScore),
+					"decision":              g.Dimensions.BotManagementDecision,
+					"verified_bot_category": g.Dimensions.VerifiedBotCategory,
+				}, g.Avg.SampleInterval)).Add(sampled(g.Count, g.Avg.SampleInterval))
+		}
+		sampleInterval.set(name, account, sampledDatasetBots)
 	}
 }
 
-func addLoadBalancingRequestsAdaptiveGroups(z *lbResp, name string, account string) {
-	// Clear stale series for this zone/account
-	label := prometheus.Labels{"zone": name, "account": account}
-	poolRequestsTotal.DeletePartialMatch(label)
-
-	for _, g := range z.LoadBalancingRequestsAdaptiveGroups {
-		poolRequestsTotal.With(
-			prometheus.Labels{
-				"zone":               name,
-				"account":            account,
-				"load_balancer_name": g.Dimensions.LbName,
-				"pool_name":          g.Dimensions.SelectedPoolName,
-				"origin_name":        g.Dimensions.SelectedOriginName,
-			}).Add(float64(g.Count))
+// Bot score buckets as presented in the Cloudflare dashboard.
+// A score of 0 means the request was not scored.
+func getBotScoreBucket(score uint8) string {
+	switch {
+	case score == 0:
+		return "not_computed"
+	case score == 1:
+		return "automated"
+	case score < 30:
+		return "likely_automated"
+	default:
+		return "likely_human"
 	}
 }
 
-func addLoadBalancingRequestsAdaptive(z *lbResp, name string, account string) {
-	// Clear stale series for this zone/account
-	label := prometheus.Labels{"zone": name, "account": account}
+func fetchSecurityAnalytics(zones []cfzones.Zone, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// None of the below referenced metrics are available in the free tier
+	if viper.GetBool("free_tier") {
+		return
+	}
+
+	zoneIDs := extractZoneIDs(zones)
+	if len(zoneIDs) == 0 {
+		return
+	}
+
+	r, err := fetchSecurityTotals(zoneIDs)
+	if err != nil {
+		log.Error("failed to fetch security analytics: ", err)
+		return
+	}
+
+	for _, z := range r.Viewer.Zones {
+		name, account := findZoneAccountName(zones, z.ZoneTag)
+		z := z
+
+		rulesets := fetchSecurityRulesets(z.ZoneTag)
+		addRulesetInfo(rulesets, name, account)
+		addSecurityRuleHits(&z, rulesets, name, account)
+		addWAFAttackScoreGroups(&z, name, account)
+	}
+}
+
+func addRulesetInfo(rulesets []securityRuleset, name string, account string) {
+	// Clear stale series for this zone/account, rulesets can be removed or updated
+	label := prometheus.Labels{"zone": name, "account": account}
+	zoneRulesetInfo.DeletePartialMatch(label)
+	zoneWAFOWASPScoreThreshold.DeletePartialMatch(label)
+
+	for _, rs := range rulesets {
+		if rs.DeploysOWASP {
+			zoneWAFOWASPScoreThreshold.With(
+				prometheus.Labels{
+					"zone":       name,
+					"account":    account,
+					"ruleset_id": rs.ID,
+				}).Set(float64(rs.OWASPScoreThreshold))
+		}
+
+		zoneRulesetInfo.With(
+			prometheus.Labels{
+				"zone":       name,
+				"account":    account,
+				"ruleset_id": rs.ID,
+				"ruleset":    rs.Name,
+				"phase":      rs.Phase,
+				"kind":       rs.Kind,
+				"version":    rs.Version,
+			}).Set(float64(1))
+	}
+}
+
+func addSecurityRuleHits(z *zoneRespSecurity, rulesets []securityRuleset, name string, account string) {
+	// Clear stale series for this zone/account
+	label := prometheus.Labels{"zone": name, "account": account}
+	zoneSecurityRuleHitsCount.DeletePartialMatch(label)
+	zoneWAFOWASPEventsCount.DeletePartialMatch(label)
+
+	rulesetsByID := make(map[string]securityRuleset, len(rulesets))
+	for _, rs := range rulesets {
+		rulesetsByID[rs.ID] = rs
+	}
+
+	var sampleInterval sampleIntervalAverage
+	for _, g := range z.FirewallEventsAdaptiveGroups {
+		sampleInterval.add(g.Count, g.Avg.SampleInterval)
+		// Events not raised by a ruleset (e.g. IP access rules, Bot Fight Mode)
+		// are reported through cloudflare_zone_firewall_events_count only.
+		if g.Dimensions.RulesetID == "" {
+			continue
+		}
+
+		rs := rulesetsByID[g.Dimensions.RulesetID]
+		ruleName := normalizeRuleName(rs.Rules[g.Dimensions.RuleID])
+
+		zoneSecurityRuleHitsCount.With(sampledLabels(
+			prometheus.Labels{
+				"zone":       name,
+				"account":    account,
+				"phase":      rs.Phase,
+				"ruleset_id": g.Dimensions.RulesetID,
+				"ruleset":    rs.Name,
+				"rule_id":    g.Dimensions.RuleID,
+				"rule":       ruleName,
+				"source":     g.Dimensions.Source,
+				"action":     g.Dimensions.Action,
+			}, g.Avg.SampleInterval)).Add(sampled(g.Count, g.Avg.SampleInterval))
+
+		if g.Dimensions.RulesetID == owaspManagedRulesetID {
+			zoneWAFOWASPEventsCount.With(sampledLabels(
+				prometheus.Labels{
+					"zone":    name,
+					"account": account,
+					"rule_id": g.Dimensions.RuleID,
+					"rule":    ruleName,
+					"action":  g.Dimensions.Action,
+				}, g.Avg.SampleInterval)).Add(sampled(g.Count, g.Avg.SampleInterval))
+		}
+	}
+	sampleInterval.set(name, account, sampledDatasetSecurityEvents)
+}
+
+func addWAFAttackScoreGroups(z *zoneRespSecurity, name string, account string) {
+	// Clear stale series for this zone/account
+	label := prometheus.Labels{"zone": name, "account": account}
+	zoneWAFAttackScoreRequestsCount.DeletePartialMatch(label)
+
+	var sampleInterval sampleIntervalAverage
+	for _, g := range z.WAFAttackScoreGroups {
+		sampleInterval.add(g.Count, g.Avg.SampleInterval)
+		zoneWAFAttackScoreRequestsCount.With(sampledLabels(
+			prometheus.Labels{
+				"zone":    name,
+				"account": account,
+				"bucket":  getWAFAttackScoreBucket(g.Dimensions.WAFAttackScore),
+			}, g.Avg.SampleInterval)).Add(sampled(g.Count, g.Avg.SampleInterval))
+	}
+	sampleInterval.set(name, account, sampledDatasetWAFAttackScore)
+}
+
+// WAF attack score buckets as presented in the Cloudflare dashboard.
+// Scores outside of 1-99 mean the request was not scored.
+func getWAFAttackScoreBucket(score uint8) string {
+	switch {
+	case score == 0 || score > 99:
+		return "not_scored"
+	case score <= 20:
+		return "attack"
+	case score <= 50:
+		return "likely_attack"
+	case score <= 80:
+		return "likely_clean"
+	default:
+		return "clean"
+	}
+}
+
+func fetchAPIShieldAnalytics(zones []cfzones.Zone, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// API Shield is not available in the free tier
+	if viper.GetBool("free_tier") {
+		return
+	}
+
+	zoneIDs := extractZoneIDs(zones)
+	if len(zoneIDs) == 0 {
+		return
+	}
+
+	r, err := fetchAPIShieldTotals(zoneIDs)
+	if err != nil {
+		log.Error("failed to fetch API Shield analytics: ", err)
+		return
+	}
+
+	for _, z := range r.Viewer.Zones {
+		name, account := findZoneAccountName(zones, z.ZoneTag)
+		z := z
+
+		addAPIShieldEvents(&z, refreshAPIShieldInventory(z.ZoneTag, name, account), name, account)
+	}
+}
+
+var (
+	// Saved operations by zone, refreshed every inventory_interval
+	apiShieldOperations   = map[string][]cfapi_gateway.OperationListResponse{}
+	apiShieldOperationsMu sync.Mutex
+)
+
+// refreshAPIShieldInventory returns the saved operations of a zone. The saved
+// and discovered operations are paged listings, they are only fetched every
+// inventory_interval.
+func refreshAPIShieldInventory(zoneID string, name string, account string) []cfapi_gateway.OperationListResponse {
+	apiShieldOperationsMu.Lock()
+	cached := apiShieldOperations[zoneID]
+	apiShieldOperationsMu.Unlock()
+
+	key := "api_shield/" + zoneID
+	now := time.Now()
+	if !inventoryDue(key, now) {
+		return cached
+	}
+
+	operations := fetchAPIShieldOperations(zoneID)
+	if operations == nil {
+		return cached
+	}
+	if !addAPIShieldDiscoveredEndpoints(zoneID, name, account) {
+		return operations
+	}
+
+	apiShieldOperationsMu.Lock()
+	apiShieldOperations[zoneID] = operations
+	apiShieldOperationsMu.Unlock()
+	markInventoryRefreshed(key, now)
+	return operations
+}
+
+func addAPIShieldDiscoveredEndpoints(zoneID string, name string, account string) bool {
+	operations := fetchAPIShieldDiscoveredOperations(zoneID)
+	if operations == nil {
+		return false
+	}
+
+	// Clear stale series for this zone/account
+	label := prometheus.Labels{"zone": name, "account": account}
+	zoneAPIShieldDiscoveredEndpoints.DeletePartialMatch(label)
+
+	states := make(map[string]int)
+	for _, o := range operations {
+		states[string(o.State)]++
+	}
+	for state, count := range states {
+		zoneAPIShieldDiscoveredEndpoints.With(
+			prometheus.Labels{
+				"zone":    name,
+				"account": account,
+				"state":   state,
+			}).Set(float64(count))
+	}
+	return true
+}
+
+func addAPIShieldEvents(z *zoneRespAPIShield, operations []cfapi_gateway.OperationListResponse, name string, account string) {
+	// Clear stale series for this zone/account
+	label := prometheus.Labels{"zone": name, "account": account}
+	zoneAPIShieldSchemaViolationsCount.DeletePartialMatch(label)
+	zoneAPIShieldSequenceMitigationCount.DeletePartialMatch(label)
+
+	matchers := buildAPIShieldOperationMatchers(operations)
+	var sampleInterval sampleIntervalAverage
+	for _, g := range z.FirewallEventsAdaptiveGroups {
+		sampleInterval.add(g.Count, g.Avg.SampleInterval)
+		endpoint, operationID := matchAPIShieldOperation(matchers,
+			g.Dimensions.ClientRequestHTTPHost,
+			g.Dimensions.ClientRequestHTTPMethodName,
+			g.Dimensions.ClientRequestPath)
+
+		labels := prometheus.Labels{
+			"zone":         name,
+			"account":      account,
+			"host":         g.Dimensions.ClientRequestHTTPHost,
+			"method":       g.Dimensions.ClientRequestHTTPMethodName,
+			"endpoint":     endpoint,
+			"operation_id": operationID,
+			"action":       g.Dimensions.Action,
+		}
+
+		switch g.Dimensions.Source {
+		case "apiShieldSchemaValidation":
+			zoneAPIShieldSchemaViolationsCount.With(sampledLabels(labels, g.Avg.SampleInterval)).Add(sampled(g.Count, g.Avg.SampleInterval))
+		case "apiShieldSequenceMitigation":
+			zoneAPIShieldSequenceMitigationCount.With(sampledLabels(labels, g.Avg.SampleInterval)).Add(sampled(g.Count, g.Avg.SampleInterval))
+		}
+	}
+	sampleInterval.set(name, account, sampledDatasetAPIShield)
+}
+
+type apiShieldOperationMatcher struct {
+	host        string
+	method      string
+	endpoint    string
+	operationID string
+	pattern     *regexp.Regexp
+}
+
+// Endpoints are URI templates, e.g. /api/users/{var1}, where every variable
+// matches exactly one path segment.
+var apiShieldEndpointVariable = regexp.MustCompile(`\\\{[^/]*?\\\}`)
+
+func buildAPIShieldOperationMatchers(operations []cfapi_gateway.OperationListResponse) []apiShieldOperationMatcher {
+	matchers := make([]apiShieldOperationMatcher, 0, len(operations))
+	for _, o := range operations {
+		expr := apiShieldEndpointVariable.ReplaceAllString(regexp.QuoteMeta(o.Endpoint), "[^/]+")
+		pattern, err := regexp.Compile("^" + expr + "$")
+		if err != nil {
+			log.Errorf("error compiling API Shield endpoint %s: %v", o.Endpoint, err)
+			continue
+		}
+		matchers = append(matchers, apiShieldOperationMatcher{
+			host:        o.Host,
+			method:      string(o.Method),
+			endpoint:    o.Endpoint,
+			operationID: o.OperationID,
+			pattern:     pattern,
+		})
+	}
+	return matchers
+}
+
+// Requests not matching any saved operation are reported with empty endpoint and
+// operation_id labels, raw paths would make the cardinality unbounded.
+func matchAPIShieldOperation(matchers []apiShieldOperationMatcher, host string, method string, path string) (string, string) {
+	for _, m := range matchers {
+		if m.host == host && m.method == method && m.pattern.MatchString(path) {
+			return m.endpoint, m.operationID
+		}
+	}
+	return "", ""
+}
+
+type pageShieldScriptState struct {
+	hash      string
+	changedAt time.Time
+}
+
+var (
+	// Script hashes by zone and script ID, used to detect changed scripts
+	pageShieldScriptStates   = map[string]map[string]pageShieldScriptState{}
+	pageShieldScriptStatesMu sync.Mutex
+)
+
+const (
+	// Page Shield scores range from 1 to 99, lower scores are more likely malicious
+	pageShieldMaliciousScoreThreshold = 50
+	pageShieldNewResourceAge          = 24 * time.Hour
+	pageShieldChangedScriptAge        = 24 * time.Hour
+)
+
+func fetchPageShieldAnalytics(zones []cfzones.Zone, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// Page Shield script monitoring details are not available in the free tier
+	if viper.GetBool("free_tier") {
+		return
+	}
+
+	// Scripts and connections are paged listings, the series are kept between
+	// refreshes every inventory_interval
+	for _, z := range zones {
+		key := "page_shield/" + z.ID
+		now := time.Now()
+		if !inventoryDue(key, now) {
+			continue
+		}
+
+		name, account := findZoneAccountName(zones, z.ID)
+		scriptsAdded := addPageShieldScripts(z.ID, name, account, now)
+		connectionsAdded := addPageShieldConnections(z.ID, name, account)
+		if scriptsAdded && connectionsAdded {
+			markInventoryRefreshed(key, now)
+		}
+	}
+}
+
+// prunePageShieldScriptStates forgets the script hashes of zones that are no
+// longer scraped.
+func prunePageShieldScriptStates(zones []cfzones.Zone) {
+	current := make(map[string]struct{}, len(zones))
+	for _, z := range zones {
+		current[z.ID] = struct{}{}
+	}
+
+	pageShieldScriptStatesMu.Lock()
+	defer pageShieldScriptStatesMu.Unlock()
+	for zoneID := range pageShieldScriptStates {
+		if _, exists := current[zoneID]; !exists {
+			delete(pageShieldScriptStates, zoneID)
+		}
+	}
+}
+
+// nextPageShieldScriptState records when the hash of a script last changed.
+// Scripts seen for the first time are not reported as changed.
+func nextPageShieldScriptState(previous pageShieldScriptState, seen bool, hash string, now time.Time) pageShieldScriptState {
+	if !seen {
+		return pageShieldScriptState{hash: hash}
+	}
+	if hash == "" || hash == previous.hash {
+		return previous
+	}
+	return pageShieldScriptState{hash: hash, changedAt: now}
+}
+
+func addPageShieldScripts(zoneID string, name string, account string, now time.Time) bool {
+	scripts := fetchPageShieldScripts(zoneID)
+	if scripts == nil {
+		return false
+	}
+
+	statuses := map[string]int{"total": 0, "malicious": 0, "new": 0, "changed": 0}
+	pageShieldScriptStatesMu.Lock()
+	previousStates := pageShieldScriptStates[zoneID]
+	states := make(map[string]pageShieldScriptState, len(scripts))
+	for _, s := range scripts {
+		statuses["total"]++
+		if s.URLReportedMalicious || s.DomainReportedMalicious ||
+			isPageShieldScoreMalicious(s.MalwareScore) ||
+			isPageShieldScoreMalicious(s.MagecartScore) ||
+			isPageShieldScoreMalicious(s.CryptominingScore) {
+			statuses["malicious"]++
+		}
+		if time.Since(s.FirstSeenAt) < pageShieldNewResourceAge {
+			statuses["new"]++
+		}
+
+		previous, seen := previousStates[s.ID]
+		state := nextPageShieldScriptState(previous, seen, s.Hash, now)
+		if !state.changedAt.IsZero() && now.Sub(state.changedAt) < pageShieldChangedScriptAge {
+			statuses["changed"]++
+		}
+		states[s.ID] = state
+	}
+	// Rebuilt from the current scripts, removed scripts are forgotten
+	pageShieldScriptStates[zoneID] = states
+	pageShieldScriptStatesMu.Unlock()
+
+	for status, count := range statuses {
+		zonePageShieldScripts.With(
+			prometheus.Labels{
+				"zone":    name,
+				"account": account,
+				"status":  status,
+			}).Set(float64(count))
+	}
+	return true
+}
+
+func addPageShieldConnections(zoneID string, name string, account string) bool {
+	connections := fetchPageShieldConnections(zoneID)
+	if connections == nil {
+		return false
+	}
+
+	statuses := map[string]int{"total": 0, "malicious": 0, "new": 0}
+	for _, c := range connections {
+		statuses["total"]++
+		if c.URLReportedMalicious || c.DomainReportedMalicious {
+			statuses["malicious"]++
+		}
+		if time.Since(c.FirstSeenAt) < pageShieldNewResourceAge {
+			statuses["new"]++
+		}
+	}
+
+	for status, count := range statuses {
+		zonePageShieldConnections.With(
+			prometheus.Labels{
+				"zone":    name,
+				"account": account,
+				"status":  status,
+			}).Set(float64(count))
+	}
+	return true
+}
+
+// A score of 0 means the script has not been scored yet.
+func isPageShieldScoreMalicious(score int64) bool {
+	return score > 0 && score < pageShieldMaliciousScoreThreshold
+}
+
+func fetchSpectrumAnalytics(zones []cfzones.Zone, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// Spectrum is not available in the free tier
+	if viper.GetBool("free_tier") {
+		return
+	}
+
+	zoneIDs := extractZoneIDs(zones)
+	if len(zoneIDs) == 0 {
+		return
+	}
+
+	r, err := fetchSpectrumTotals(zoneIDs)
+	if err != nil {
+		log.Error("failed to fetch spectrum analytics: ", err)
+		return
+	}
+
+	for _, z := range r.Viewer.Zones {
+		name, account := findZoneAccountName(zones, z.ZoneTag)
+
+		// Clear stale series for this zone/account
+		label := prometheus.Labels{"zone": name, "account": account}
+		zoneSpectrumBytes.DeletePartialMatch(label)
+		zoneSpectrumPackets.DeletePartialMatch(label)
+
+		var sampleInterval sampleIntervalAverage
+		for _, g := range z.SpectrumNetworkAnalyticsAdaptiveGroups {
+			sampleInterval.add(g.Sum.Packets, g.Avg.SampleInterval)
+			labels := prometheus.Labels{
+				"zone":       name,
+				"account":    account,
+				"app_id":     g.Dimensions.AppID,
+				"colocation": g.Dimensions.ColoCode,
+				"protocol":   g.Dimensions.IPProtocolName,
+			}
+			zoneSpectrumBytes.With(sampledLabels(labels, g.Avg.SampleInterval)).Add(sampled(g.Sum.Bits, g.Avg.SampleInterval) / 8)
+			zoneSpectrumPackets.With(sampledLabels(labels, g.Avg.SampleInterval)).Add(sampled(g.Sum.Packets, g.Avg.SampleInterval))
+		}
+		sampleInterval.set(name, account, sampledDatasetSpectrum)
+
+		// Zones without Spectrum applications have no adaptive groups, skip the
+		// connection lookup for them. Current connections are looked up once
+		// per zone, a lookup per colocation exceeds the API rate limit.
+		if len(z.SpectrumNetworkAnalyticsAdaptiveGroups) == 0 {
+			continue
+		}
+
+		current := fetchSpectrumCurrentConnections(z.ZoneTag)
+		if current == nil {
+			continue
+		}
+		zoneSpectrumActiveConnections.DeletePartialMatch(label)
+		for _, app := range current {
+			zoneSpectrumActiveConnections.With(
+				prometheus.Labels{
+					"zone":    name,
+					"account": account,
+					"app_id":  app.AppID,
+				}).Set(app.Connections)
+		}
+	}
+}
+
+// isDatasetUnavailable reports whether a GraphQL query failed because the
+// account does not have the product behind the dataset.
+func isDatasetUnavailable(err error) bool {
+	return strings.Contains(err.Error(), "does not have access")
+}
+
+func fetchMagicTransitAnalyticsForAccount(account cfaccounts.Account, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	if viper.GetBool("free_tier") {
+		return
+	}
+
+	// Accounts without Magic Transit are retried every inventory_interval
+	// instead of failing the query every scrape.
+	key := "magic_transit/" + account.ID
+	now := time.Now()
+	if !inventoryDue(key, now) {
+		return
+	}
+
+	r, err := fetchMagicTransitTotals(account.ID)
+	if err != nil {
+		if isDatasetUnavailable(err) {
+			log.Debug("magic transit is not available for account ", account.ID, ", skipping")
+			markInventoryRefreshed(key, now)
+			return
+		}
+		log.Error("failed to fetch magic transit analytics for account ", account.ID, ": ", err)
+		return
+	}
+
+	// Clear stale series for this account
+	label := prometheus.Labels{"account": account.Name}
+	magicTransitBits.DeletePartialMatch(label)
+	magicTransitPackets.DeletePartialMatch(label)
+
+	for _, acc := range r.Viewer.Accounts {
+		for _, g := range acc.MagicTransitNetworkAnalyticsAdaptiveGroups {
+			labels := prometheus.Labels{
+				"account":    account.Name,
+				"prefix":     g.Dimensions.IPDestinationSubnet,
+				"protocol":   g.Dimensions.IPProtocolName,
+				"colocation": g.Dimensions.ColoCode,
+				"outcome":    g.Dimensions.Outcome,
+			}
+			magicTransitBits.With(sampledLabels(labels, g.Avg.SampleInterval)).Add(sampled(g.Sum.Bits, g.Avg.SampleInterval))
+			magicTransitPackets.With(sampledLabels(labels, g.Avg.SampleInterval)).Add(sampled(g.Sum.Packets, g.Avg.SampleInterval))
+		}
+	}
+}
+
+func fetchDDoSAnalytics(zones []cfzones.Zone, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// L7 DDoS attack analytics are not available in the free tier
+	if viper.GetBool("free_tier") {
+		return
+	}
+
+	zoneIDs := extractZoneIDs(zones)
+	if len(zoneIDs) == 0 {
+		return
+	}
+
+	r, err := fetchDDoSTotals(zoneIDs)
+	if err != nil {
+		log.Error("failed to fetch DDoS analytics: ", err)
+		return
+	}
+
+	for _, z := range r.Viewer.Zones {
+		name, account := findZoneAccountName(zones, z.ZoneTag)
+
+		// Clear stale series for this zone/account
+		label := prometheus.Labels{"zone": name, "account": account}
+		zoneDDoSMitigatedRequestsCount.DeletePartialMatch(label)
+		zoneDDoSAttacks.DeletePartialMatch(label)
+
+		// HTTP DDoS events carry no attack ID. Each managed rule matches one
+		// attack vector, named by its description, and a rule mitigating
+		// requests in the window is counted as one attack.
+		var mitigated uint64
+		var sampleInterval sampleIntervalAverage
+		attacks := make(map[string]map[string]struct{})
+		for _, g := range z.FirewallEventsAdaptiveGroups {
+			mitigated += g.Count
+			sampleInterval.add(g.Count, g.Avg.SampleInterval)
+			vector := normalizeRuleName(g.Dimensions.Description)
+			zoneDDoSMitigatedRequestsCount.With(sampledLabels(
+				prometheus.Labels{
+					"zone":          name,
+					"account":       account,
+					"attack_vector": vector,
+					"rule_id":       g.Dimensions.RuleID,
+					"action":        g.Dimensions.Action,
+				}, g.Avg.SampleInterval)).Add(sampled(g.Count, g.Avg.SampleInterval))
+
+			if _, exists := attacks[vector]; !exists {
+				attacks[vector] = make(map[string]struct{})
+			}
+			attacks[vector][g.Dimensions.RuleID] = struct{}{}
+		}
+
+		sampleInterval.set(name, account, sampledDatasetL7DDoS)
+
+		for vector, ids := range attacks {
+			zoneDDoSAttacks.With(prometheus.Labels{"zone": name, "account": account, "attack_vector": vector}).Set(float64(len(ids)))
+		}
+
+		attackInProgress := 0
+		if mitigated > 0 {
+			attackInProgress = 1
+		}
+		zoneDDoSAttackInProgress.With(label).Set(float64(attackInProgress))
+	}
+}
+
+func fetchDDoSAnalyticsForAccount(account cfaccounts.Account, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	if viper.GetBool("free_tier") {
+		return
+	}
+
+	// Accounts without Magic Transit or Spectrum are retried every
+	// inventory_interval instead of failing the query every scrape.
+	key := "ddos/" + account.ID
+	now := time.Now()
+	if !inventoryDue(key, now) {
+		return
+	}
+
+	r, err := fetchDDoSAccount(account.ID)
+	if err != nil {
+		if isDatasetUnavailable(err) {
+			log.Debug("L3/4 DDoS analytics are not available for account ", account.ID, ", skipping")
+			markInventoryRefreshed(key, now)
+			return
+		}
+		log.Error("failed to fetch DDoS analytics for account ", account.ID, ": ", err)
+		return
+	}
+
+	// Clear stale series for this account
+	label := prometheus.Labels{"account": account.Name}
+	ddosAttacks.DeletePartialMatch(label)
+	ddosPackets.DeletePartialMatch(label)
+	ddosBits.DeletePartialMatch(label)
+
+	attacks := make(map[string]map[string]struct{})
+	for _, acc := range r.Viewer.Accounts {
+		for _, g := range acc.DosdNetworkAnalyticsAdaptiveGroups {
+			labels := prometheus.Labels{
+				"account":       account.Name,
+				"attack_vector": g.Dimensions.AttackVector,
+				"rule_id":       g.Dimensions.RuleID,
+				"outcome":       g.Dimensions.Outcome,
+			}
+			ddosPackets.With(sampledLabels(labels, g.Avg.SampleInterval)).Add(sampled(g.Sum.Packets, g.Avg.SampleInterval))
+			ddosBits.With(sampledLabels(labels, g.Avg.SampleInterval)).Add(sampled(g.Sum.Bits, g.Avg.SampleInterval))
+
+			if g.Dimensions.AttackID == "" {
+				continue
+			}
+			if _, exists := attacks[g.Dimensions.AttackVector]; !exists {
+				attacks[g.Dimensions.AttackVector] = make(map[string]struct{})
+			}
+			attacks[g.Dimensions.AttackVector][g.Dimensions.AttackID] = struct{}{}
+		}
+	}
+
+	for vector, ids := range attacks {
+		ddosAttacks.With(prometheus.Labels{"account": account.Name, "attack_vector": vector}).Set(float64(len(ids)))
+	}
+
+	attackInProgress := 0
+	if len(attacks) > 0 {
+		attackInProgress = 1
+	}
+	ddosAttackInProgress.With(label).Set(float64(attackInProgress))
+}
+
+var rumQuantiles = []string{"0.5", "0.75", "0.9", "0.99"}
+
+type rumSeries struct {
+	siteTag    string
+	pathClass  string
+	country    countryLabels
+	deviceType string
+}
+
+// Quantiles of several paths within the same path class cannot be merged
+// exactly, they are approximated by an average weighted by the page loads.
+type rumWebVitals struct {
+	weight float64
+	lcp    [4]float64
+	inp    [4]float64
+	cls    [4]float64
+	ttfb   [4]float64
+	fcp    [4]float64
+}
+
+func fetchRUMAnalyticsForAccount(account cfaccounts.Account, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	r, err := fetchRUMTotals(account.ID)
+	if err != nil {
+		log.Error("failed to fetch web analytics for account ", account.ID, ": ", err)
+		return
+	}
+
+	// Clear stale series for this account
+	label := prometheus.Labels{"account": account.Name}
+	rumPageLoads.DeletePartialMatch(label)
+	rumLargestContentfulPaint.DeletePartialMatch(label)
+	rumInteractionToNextPaint.DeletePartialMatch(label)
+	rumCumulativeLayoutShift.DeletePartialMatch(label)
+	rumTimeToFirstByte.DeletePartialMatch(label)
+	rumFirstContentfulPaint.DeletePartialMatch(label)
+
+	for _, acc := range r.Viewer.Accounts {
+		for _, g := range acc.RUMPerformanceEventsAdaptiveGroups {
+			rs := newRUMSeries(g.Dimensions)
+			rumPageLoads.With(rs.labels(account.Name)).Add(float64(g.Count))
+		}
+
+		vitals := make(map[rumSeries]*rumWebVitals)
+		for _, g := range acc.RUMWebVitalsEventsAdaptiveGroups {
+			rs := newRUMSeries(g.Dimensions)
+			v, exists := vitals[rs]
+			if !exists {
+				v = &rumWebVitals{}
+				vitals[rs] = v
+			}
+
+			w := float64(g.Count)
+			q := g.Quantiles
+			v.weight += w
+			addWeightedQuantiles(&v.lcp, w, q.LargestContentfulPaintP50, q.LargestContentfulPaintP75, q.LargestContentfulPaintP90, q.LargestContentfulPaintP99)
+			addWeightedQuantiles(&v.inp, w, q.InteractionToNextPaintP50, q.InteractionToNextPaintP75, q.InteractionToNextPaintP90, q.InteractionToNextPaintP99)
+			addWeightedQuantiles(&v.cls, w, q.CumulativeLayoutShiftP50, q.CumulativeLayoutShiftP75, q.CumulativeLayoutShiftP90, q.CumulativeLayoutShiftP99)
+			addWeightedQuantiles(&v.ttfb, w, q.TimeToFirstByteP50, q.TimeToFirstByteP75, q.TimeToFirstByteP90, q.TimeToFirstByteP99)
+			addWeightedQuantiles(&v.fcp, w, q.FirstContentfulPaintP50, q.FirstContentfulPaintP75, q.FirstContentfulPaintP90, q.FirstContentfulPaintP99)
+		}
+
+		for rs, v := range vitals {
+			if v.weight == 0 {
+				continue
+			}
+			for i, quantile := range rumQuantiles {
+				labels := rs.labels(account.Name)
+				labels["quantile"] = quantile
+				rumLargestContentfulPaint.With(labels).Set(v.lcp[i] / v.weight)
+				rumInteractionToNextPaint.With(labels).Set(v.inp[i] / v.weight)
+				rumCumulativeLayoutShift.With(labels).Set(v.cls[i] / v.weight)
+				rumTimeToFirstByte.With(labels).Set(v.ttfb[i] / v.weight)
+				rumFirstContentfulPaint.With(labels).Set(v.fcp[i] / v.weight)
+			}
+		}
+	}
+}
+
+func newRUMSeries(d rumDimensions) rumSeries {
+	return rumSeries{
+		siteTag:    d.SiteTag,
+		pathClass:  getRUMPathClass(d.RequestPath),
+		country:    normalizeCountry(d.CountryName),
+		deviceType: d.DeviceType,
+	}
+}
+
+func (rs rumSeries) labels(account string) prometheus.Labels {
+	return rs.country.apply(prometheus.Labels{
+		"account":     account,
+		"site_tag":    rs.siteTag,
+		"path_class":  rs.pathClass,
+		"device_type": rs.deviceType,
+	})
+}
+
+func addWeightedQuantiles(dst *[4]float64, weight float64, quantiles ...float64) {
+	for i, q := range quantiles {
+		dst[i] += q * weight
+	}
+}
+
+// Paths are grouped by their first segment to keep the cardinality bounded,
+// e.g. /blog/2024/hello-world becomes /blog/*. Top level pages, e.g. /about
+// or /<product-id>, are grouped together as /*. A trailing slash marks a
+// directory, /blog/ is the index of /blog/* and grouped with it.
+func getRUMPathClass(path string) string {
+	trimmed := strings.TrimLeft(path, "/")
+	if trimmed == "" {
+		return "/"
+	}
+	first, _, nested := strings.Cut(trimmed, "/")
+	if !nested {
+		return "/*"
+	}
+	return "/" + first + "/*"
+}
+
+func fetchWaitingRoomAnalytics(zones []cfzones.Zone, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// Waiting rooms are not available in the free tier
+	if viper.GetBool("free_tier") {
+		return
+	}
+
+	zoneIDs := extractZoneIDs(zones)
+	if len(zoneIDs) == 0 {
+		return
+	}
+
+	r, err := fetchWaitingRoomTotals(zoneIDs)
+	if err != nil {
+		log.Error("failed to fetch waiting room analytics: ", err)
+		return
+	}
+
+	now := time.Now()
+	for _, z := range r.Viewer.Zones {
+		name, account := findZoneAccountName(zones, z.ZoneTag)
+		rooms := fetchWaitingRooms(z.ZoneTag)
+		if rooms == nil {
+			continue
+		}
+		statuses := getWaitingRoomStatuses(z.ZoneTag, rooms, now)
+
+		// Clear stale series for this zone/account, rooms can be removed
+		label := prometheus.Labels{"zone": name, "account": account}
+		zoneWaitingRoomTotalActiveUsersLimit.DeletePartialMatch(label)
+		zoneWaitingRoomNewUsersPerMinuteLimit.DeletePartialMatch(label)
+		zoneWaitingRoomStatus.DeletePartialMatch(label)
+		zoneWaitingRoomQueuedUsers.DeletePartialMatch(label)
+		zoneWaitingRoomActiveUsers.DeletePartialMatch(label)
+		zoneWaitingRoomEstimatedWaitTime.DeletePartialMatch(label)
+		zoneWaitingRoomAdmittedUsersPerMinute.DeletePartialMatch(label)
+
+		roomsByID := make(map[string]cfwaiting_rooms.WaitingRoom, len(rooms))
+		for _, room := range rooms {
+			roomsByID[room.ID] = room
+			labels := prometheus.Labels{
+				"zone":         name,
+				"account":      account,
+				"waiting_room": room.Name,
+				"host":         room.Host,
+			}
+			zoneWaitingRoomTotalActiveUsersLimit.With(labels).Set(float64(room.TotalActiveUsers))
+			zoneWaitingRoomNewUsersPerMinuteLimit.With(labels).Set(float64(room.NewUsersPerMinute))
+
+			status, exists := statuses[room.ID]
+			if !exists {
+				continue
+			}
+			labels["status"] = status
+			zoneWaitingRoomStatus.With(labels).Set(float64(1))
+		}
+
+		for _, g := range z.WaitingRoomAnalyticsAdaptiveGroups {
+			room, exists := roomsByID[g.Dimensions.WaitingRoomID]
+			if !exists {
+				continue
+			}
+			labels := prometheus.Labels{
+				"zone":         name,
+				"account":      account,
+				"waiting_room": room.Name,
+				"host":         room.Host,
+			}
+			zoneWaitingRoomQueuedUsers.With(labels).Set(float64(g.Max.TotalQueuedUsers))
+			zoneWaitingRoomActiveUsers.With(labels).Set(float64(g.Max.TotalActiveUsers))
+			zoneWaitingRoomEstimatedWaitTime.With(labels).Set(g.Max.EstimatedWaitTime)
+			zoneWaitingRoomAdmittedUsersPerMinute.With(labels).Set(g.Avg.NewUsersPerMinute)
+		}
+	}
+}
+
+// Waiting room statuses take a REST call per room, they are refreshed every
+// inventory_interval like the worker script inventory.
+var (
+	waitingRoomStatuses   = map[string]map[string]string{}
+	waitingRoomStatusesMu sync.Mutex
+)
+
+// getWaitingRoomStatuses returns the status of the rooms of a zone by room
+// ID, fetching them again when the refresh interval elapsed or a room was
+// added.
+func getWaitingRoomStatuses(zoneID string, rooms []cfwaiting_rooms.WaitingRoom, now time.Time) map[string]string {
+	waitingRoomStatusesMu.Lock()
+	cached, added := waitingRoomStatuses[zoneID], false
+	waitingRoomStatusesMu.Unlock()
+	for _, room := range rooms {
+		if _, exists := cached[room.ID]; !exists {
+			added = true
+		}
+	}
+
+	key := "waiting_rooms/" + zoneID
+	if added || inventoryDue(key, now) {
+		statuses := make(map[string]string, len(rooms))
+		for _, room := range rooms {
+			if status := fetchWaitingRoomStatus(zoneID, room.ID); status != nil {
+				statuses[room.ID] = string(status.Status)
+			}
+		}
+		waitingRoomStatusesMu.Lock()
+		waitingRoomStatuses[zoneID] = statuses
+		waitingRoomStatusesMu.Unlock()
+		markInventoryRefreshed(key, now)
+		return statuses
+	}
+	return cached
+}
+
+func fetchLoadBalancerAnalytics(zones []cfzones.Zone, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// None of the below referenced metrics are available in the free tier
+	if viper.GetBool("free_tier") {
+		return
+	}
+
+	zoneIDs := extractZoneIDs(zones)
+	if len(zoneIDs) == 0 {
+		return
+	}
+
+	l, err := fetchLoadBalancerTotals(zoneIDs)
+	if err != nil {
+		log.Error("failed to fetch load balancer analytics: ", err)
+		return
+	}
+	for _, lb := range l.Viewer.Zones {
+		name, account := findZoneAccountName(zones, lb.ZoneTag)
+		lb := lb
+		addLoadBalancingRequestsAdaptive(&lb, name, account)
+		addLoadBalancingRequestsAdaptiveGroups(&lb, name, account)
+	}
+}
+
+func addLoadBalancingRequestsAdaptiveGroups(z *lbResp, name string, account string) {
+	// Clear stale series for this zone/account
+	label := prometheus.Labels{"zone": name, "account": account}
+	poolRequestsTotal.DeletePartialMatch(label)
+
+	var sampleInterval sampleIntervalAverage
+	for _, g := range z.LoadBalancingRequestsAdaptiveGroups {
+		count := sampled(g.Count, g.Avg.SampleInterval)
+		sampleInterval.add(g.Count, g.Avg.SampleInterval)
+		poolRequestsTotal.With(sampledLabels(
+			prometheus.Labels{
+				"zone":               name,
+				"account":            account,
+				"load_balancer_name": g.Dimensions.LbName,
+				"pool_name":          g.Dimensions.SelectedPoolName,
+				"origin_name":        g.Dimensions.SelectedOriginName,
+			}, g.Avg.SampleInterval)).Add(count)
+		addCostUsage(costLoadBalancerRequests, account, name, "", count)
+	}
+	sampleInterval.set(name, account, sampledDatasetLoadBalancing)
+}
+
+func addLoadBalancingRequestsAdaptive(z *lbResp, name string, account string) {
+	// Clear stale series for this zone/account
+	label := prometheus.Labels{"zone": name, "account": account}
 	poolHealthStatus.DeletePartialMatch(label)
 
 	for _, g := range z.LoadBalancingRequestsAdaptive {
@@ -912,7 +3468,22 @@ func fetchZeroTrustAnalyticsForAccount(account cfaccounts.Account, wg *sync.Wait
 
 func addCloudflareTunnelStatus(account cfaccounts.Account) {
 	tunnels := fetchCloudflareTunnels(account)
+	if tunnels == nil {
+		return
+	}
+	deleteStaleTunnels(account.Name, tunnels)
+
+	type connector struct {
+		tunnelID string
+		client   cfzero_trust.Client
+	}
+	var connectors []connector
+
 	for _, t := range tunnels {
+		// Clear stale series for this tunnel, it can be renamed
+		tunnelLabel := prometheus.Labels{"account": account.Name, "tunnel_id": t.ID}
+		tunnelInfo.DeletePartialMatch(tunnelLabel)
+
 		tunnelInfo.With(
 			prometheus.Labels{
 				"account":     account.Name,
@@ -927,10 +3498,19 @@ func addCloudflareTunnelStatus(account cfaccounts.Account) {
 				"tunnel_id": t.ID,
 			}).Set(float64(getCloudflareTunnelStatusValue(string(t.Status))))
 
-		// Each client/connector can open many connections to the Cloudflare edge,
-		// we opt to not expose metrics for each individual connection. We do expose
-		// an informational metric for each client/connector however.
 		clients := fetchCloudflareTunnelConnectors(account, t.ID)
+		if clients == nil {
+			continue
+		}
+
+		// Clear stale series for this tunnel, connectors and connections come and go
+		tunnelConnectorInfo.DeletePartialMatch(tunnelLabel)
+		tunnelConnectorActiveConnections.DeletePartialMatch(tunnelLabel)
+		tunnelConnectorVersionSkew.DeletePartialMatch(tunnelLabel)
+		tunnelConnectionInfo.DeletePartialMatch(tunnelLabel)
+		tunnelConnectionAge.DeletePartialMatch(tunnelLabel)
+		tunnelConnectionPendingReconnect.DeletePartialMatch(tunnelLabel)
+
 		for _, c := range clients {
 			originIP := ""
 			if len(c.Conns) > 0 {
@@ -953,8 +3533,147 @@ func addCloudflareTunnelStatus(account cfaccounts.Account) {
 					"tunnel_id": t.ID,
 					"client_id": c.ID,
 				}).Set(float64(len(c.Conns)))
+
+			for _, conn := range c.Conns {
+				addCloudflareTunnelConnection(account.Name, t.ID, c.ID, conn)
+			}
+			connectors = append(connectors, connector{tunnelID: t.ID, client: c})
+		}
+	}
+
+	// Version skew is measured against the newest cloudflared of the account
+	latest := ""
+	for _, c := range connectors {
+		if compareCloudflaredVersions(c.client.Version, latest) > 0 {
+			latest = c.client.Version
+		}
+	}
+	for _, c := range connectors {
+		skew := 0
+		if compareCloudflaredVersions(c.client.Version, latest) < 0 {
+			skew = 1
+		}
+		tunnelConnectorVersionSkew.With(
+			prometheus.Labels{
+				"account":        account.Name,
+				"tunnel_id":      c.tunnelID,
+				"client_id":      c.client.ID,
+				"version":        c.client.Version,
+				"latest_version": latest,
+			}).Set(float64(skew))
+	}
+
+	addCloudflareTunnelTraffic(account, tunnels)
+}
+
+func addCloudflareTunnelConnection(account string, tunnelID string, clientID string, conn cfzero_trust.ClientConn) {
+	tunnelConnectionInfo.With(
+		prometheus.Labels{
+			"account":       account,
+			"tunnel_id":     tunnelID,
+			"client_id":     clientID,
+			"connection_id": conn.ID,
+			"colo":          conn.ColoName,
+			"origin_ip":     conn.OriginIP,
+		}).Set(float64(1))
+
+	labels := prometheus.Labels{
+		"account":       account,
+		"tunnel_id":     tunnelID,
+		"client_id":     clientID,
+		"connection_id": conn.ID,
+		"colo":          conn.ColoName,
+	}
+	if !conn.OpenedAt.IsZero() {
+		tunnelConnectionAge.With(labels).Set(time.Since(conn.OpenedAt).Seconds())
+	}
+	pendingReconnect := 0
+	if conn.IsPendingReconnect {
+		pendingReconnect = 1
+	}
+	tunnelConnectionPendingReconnect.With(labels).Set(float64(pendingReconnect))
+}
+
+func addCloudflareTunnelTraffic(account cfaccounts.Account, tunnels []cfzero_trust.TunnelListResponse) {
+	r, err := fetchTunnelTotals(account.ID)
+	if err != nil {
+		return
+	}
+
+	tunnelNames := make(map[string]string, len(tunnels))
+	for _, t := range tunnels {
+		tunnelNames[t.ID] = t.Name
+	}
+
+	for _, acc := range r.Viewer.Accounts {
+		for _, g := range acc.TunnelGroups {
+			name, exists := tunnelNames[g.Dimensions.TunnelID]
+			if !exists {
+				// Deleted tunnels are cleaned up and must not come back
+				continue
+			}
+			tunnelRequests.With(prometheus.Labels{"account": account.Name, "tunnel_id": g.Dimensions.TunnelID, "tunnel_name": name}).Add(float64(g.Count))
+			tunnelBytes.With(prometheus.Labels{"account": account.Name, "tunnel_id": g.Dimensions.TunnelID, "tunnel_name": name, "direction": "in"}).Add(float64(g.Sum.RequestBytes))
+			tunnelBytes.With(prometheus.Labels{"account": account.Name, "tunnel_id": g.Dimensions.TunnelID, "tunnel_name": name, "direction": "out"}).Add(float64(g.Sum.ResponseBytes))
+		}
+	}
+}
+
+// deleteStaleTunnels removes all series of tunnels that were exported by the
+// previous scrape of the account but no longer exist.
+func deleteStaleTunnels(account string, tunnels []cfzero_trust.TunnelListResponse) {
+	current := make(map[string]struct{}, len(tunnels))
+	for _, t := range tunnels {
+		current[t.ID] = struct{}{}
+	}
+
+	tunnelsSeenMu.Lock()
+	defer tunnelsSeenMu.Unlock()
+
+	for id := range tunnelsSeen[account] {
+		if _, exists := current[id]; exists {
+			continue
+		}
+		label := prometheus.Labels{"account": account, "tunnel_id": id}
+		tunnelInfo.DeletePartialMatch(label)
+		tunnelHealthStatus.DeletePartialMatch(label)
+		tunnelConnectorInfo.DeletePartialMatch(label)
+		tunnelConnectorActiveConnections.DeletePartialMatch(label)
+		tunnelConnectorVersionSkew.DeletePartialMatch(label)
+		tunnelConnectionInfo.DeletePartialMatch(label)
+		tunnelConnectionAge.DeletePartialMatch(label)
+		tunnelConnectionPendingReconnect.DeletePartialMatch(label)
+		tunnelRequests.DeletePartialMatch(label)
+		tunnelBytes.DeletePartialMatch(label)
+	}
+	tunnelsSeen[account] = current
+}
+
+// compareCloudflaredVersions compares cloudflared versions such as 2024.2.1
+// numerically, returning -1, 0 or 1. An empty version sorts first.
+func compareCloudflaredVersions(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y string
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		xi, xerr := strconv.Atoi(x)
+		yi, yerr := strconv.Atoi(y)
+		switch {
+		case xerr == nil && yerr == nil:
+			if xi != yi {
+				return cmp.Compare(xi, yi)
+			}
+		case x != y:
+			return cmp.Compare(x, y)
 		}
 	}
+	return 0
 }
 
 // The status of the tunnel.
@@ -978,3 +3697,315 @@ func getCloudflareTunnelStatusValue(status string) uint8 {
 		return 255
 	}
 }
+
+func fetchEmailRoutingAnalytics(zones []cfzones.Zone, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	zoneIDs := extractZoneIDs(zones)
+	if len(zoneIDs) == 0 {
+		return
+	}
+
+	r, err := fetchEmailRoutingTotals(zoneIDs)
+	if err != nil {
+		log.Error("failed to fetch email routing analytics: ", err)
+		return
+	}
+
+	for _, z := range r.Viewer.Zones {
+		name, account := findZoneAccountName(zones, z.ZoneTag)
+		ruleNames := refreshEmailRoutingRules(z.ZoneTag, name, account)
+
+		var sampleInterval sampleIntervalAverage
+		for _, g := range z.EmailRoutingAdaptiveGroups {
+			sampleInterval.add(g.Count, g.Avg.SampleInterval)
+			rule := g.Dimensions.RuleMatched
+			if n, exists := ruleNames[rule]; exists && n != "" {
+				rule = n
+			}
+			zoneEmailRoutingMessages.With(sampledLabels(prometheus.Labels{
+				"zone":    name,
+				"account": account,
+				"rule":    rule,
+				"action":  g.Dimensions.Action,
+				"status":  g.Dimensions.Status,
+			}, g.Avg.SampleInterval)).Add(sampled(g.Count, g.Avg.SampleInterval))
+			zoneEmailRoutingAuthResults.With(sampledLabels(prometheus.Labels{
+				"zone":    name,
+				"account": account,
+				"status":  g.Dimensions.Status,
+				"spf":     g.Dimensions.SPF,
+				"dkim":    g.Dimensions.DKIM,
+				"dmarc":   g.Dimensions.DMARC,
+			}, g.Avg.SampleInterval)).Add(sampled(g.Count, g.Avg.SampleInterval))
+		}
+		sampleInterval.set(name, account, sampledDatasetEmailRouting)
+	}
+}
+
+var (
+	// Rule names by zone and rule ID, refreshed every inventory_interval
+	emailRoutingRuleNames   = map[string]map[string]string{}
+	emailRoutingRuleNamesMu sync.Mutex
+)
+
+// refreshEmailRoutingRules returns the rule names of a zone by rule ID. The
+// rules are a paged listing, they are only fetched every inventory_interval
+// and the series are kept in between.
+func refreshEmailRoutingRules(zoneID string, name string, account string) map[string]string {
+	emailRoutingRuleNamesMu.Lock()
+	cached := emailRoutingRuleNames[zoneID]
+	emailRoutingRuleNamesMu.Unlock()
+
+	key := "email_routing_rules/" + zoneID
+	now := time.Now()
+	if !inventoryDue(key, now) {
+		return cached
+	}
+	rules := fetchEmailRoutingRules(zoneID)
+	if rules == nil {
+		return cached
+	}
+
+	// Clear stale series for this zone/account, rules can be removed
+	zoneEmailRoutingRules.DeletePartialMatch(prometheus.Labels{"zone": name, "account": account})
+
+	ruleNames := make(map[string]string, len(rules))
+	for _, rule := range rules {
+		ruleNames[rule.ID] = rule.Name
+		zoneEmailRoutingRules.With(prometheus.Labels{
+			"zone":    name,
+			"account": account,
+			"enabled": strconv.FormatBool(bool(rule.Enabled)),
+		}).Inc()
+	}
+
+	emailRoutingRuleNamesMu.Lock()
+	emailRoutingRuleNames[zoneID] = ruleNames
+	emailRoutingRuleNamesMu.Unlock()
+	markInventoryRefreshed(key, now)
+	return ruleNames
+}
+
+func fetchEmailRoutingAddressesForAccount(account cfaccounts.Account, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	addresses := fetchEmailRoutingAddresses(account.ID)
+	if addresses == nil {
+		return
+	}
+
+	var verified, unverified int
+	for _, a := range addresses {
+		if a.Verified.IsZero() {
+			unverified++
+		} else {
+			verified++
+		}
+	}
+
+	emailRoutingDestinationAddresses.With(prometheus.Labels{"account": account.Name, "verified": "true"}).Set(float64(verified))
+	emailRoutingDestinationAddresses.With(prometheus.Labels{"account": account.Name, "verified": "false"}).Set(float64(unverified))
+}
+
+func fetchArgoAnalytics(zones []cfzones.Zone, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// Argo and Tiered Cache analytics are not available in the free tier
+	if viper.GetBool("free_tier") {
+		return
+	}
+
+	zoneIDs := extractZoneIDs(zones)
+	if len(zoneIDs) == 0 {
+		return
+	}
+
+	r, err := fetchArgoTotals(zoneIDs)
+	if err != nil {
+		log.Error("failed to fetch argo analytics: ", err)
+		return
+	}
+
+	for _, z := range r.Viewer.Zones {
+		name, account := findZoneAccountName(zones, z.ZoneTag)
+		addArgoGroups(&z, name, account)
+		addTieredCacheGroups(&z, name, account)
+	}
+}
+
+func addArgoGroups(z *zoneRespArgo, name string, account string) {
+	// Clear stale series for this zone/account
+	label := prometheus.Labels{"zone": name, "account": account}
+	zoneArgoRequests.DeletePartialMatch(label)
+	zoneArgoOriginResponseDuration.DeletePartialMatch(label)
+	zoneArgoSmartRoutedRatio.DeletePartialMatch(label)
+
+	total := make(map[string]uint64)
+	smartRouted := make(map[string]uint64)
+	var sampleInterval sampleIntervalAverage
+	for _, g := range z.ArgoGroups {
+		origin := g.Dimensions.OriginIP
+		routed := g.Dimensions.IsSmartRouted == 1
+		labels := prometheus.Labels{"zone": name, "account": account, "origin": origin, "smart_routed": strconv.FormatBool(routed)}
+		zoneArgoRequests.With(sampledLabels(labels, g.Avg.SampleInterval)).Add(sampled(g.Count, g.Avg.SampleInterval))
+		sampleInterval.add(g.Count, g.Avg.SampleInterval)
+		zoneArgoOriginResponseDuration.With(labels).Set(g.Avg.OriginResponseDurationMs)
+
+		total[origin] += g.Count
+		if routed {
+			smartRouted[origin] += g.Count
+			addCostUsage(costArgoBytes, account, name, "", sampled(g.Sum.EdgeResponseBytes, g.Avg.SampleInterval))
+		}
+	}
+	sampleInterval.set(name, account, sampledDatasetArgo)
+
+	for origin, count := range total {
+		if count == 0 {
+			continue
+		}
+		zoneArgoSmartRoutedRatio.With(prometheus.Labels{"zone": name, "account": account, "origin": origin}).Set(float64(smartRouted[origin]) / float64(count))
+	}
+}
+
+func addTieredCacheGroups(z *zoneRespArgo, name string, account string) {
+	// Clear stale series for this zone/account
+	label := prometheus.Labels{"zone": name, "account": account}
+	zoneTieredCacheRequests.DeletePartialMatch(label)
+	zoneTieredCacheUpperTierHitRatio.DeletePartialMatch(label)
+
+	total := make(map[string]uint64)
+	hits := make(map[string]uint64)
+	var sampleInterval sampleIntervalAverage
+	for _, g := range z.TieredCacheGroups {
+		upperTier := g.Dimensions.UpperTierColoName
+		zoneTieredCacheRequests.With(sampledLabels(prometheus.Labels{"zone": name, "account": account, "upper_tier": upperTier, "cache_status": g.Dimensions.CacheStatus}, g.Avg.SampleInterval)).Add(sampled(g.Count, g.Avg.SampleInterval))
+		sampleInterval.add(g.Count, g.Avg.SampleInterval)
+
+		total[upperTier] += g.Count
+		switch g.Dimensions.CacheStatus {
+		case "hit", "stale", "updating", "revalidated":
+			hits[upperTier] += g.Count
+		}
+	}
+	sampleInterval.set(name, account, sampledDatasetTieredCache)
+
+	for upperTier, count := range total {
+		if count == 0 {
+			continue
+		}
+		zoneTieredCacheUpperTierHitRatio.With(prometheus.Labels{"zone": name, "account": account, "upper_tier": upperTier}).Set(float64(hits[upperTier]) / float64(count))
+	}
+}
+
+func fetchTopNAnalytics(zones []cfzones.Zone, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// Adaptive request analytics are not available in the free tier
+	if viper.GetBool("free_tier") {
+		return
+	}
+
+	zoneIDs := extractZoneIDs(zones)
+	if len(zoneIDs) == 0 {
+		return
+	}
+
+	limits := make(map[string]int, len(topNDimensions))
+	for _, d := range topNDimensions {
+		limits[d.alias] = viper.GetInt(d.flag)
+	}
+
+	for dataset, add := range map[string]func(*zoneRespTopN, string, string){
+		topNDatasetHTTP:     addTopNGroups,
+		topNDatasetFirewall: addTopNThreatGroups,
+	} {
+		r, err := fetchTopNTotals(zoneIDs, dataset, limits)
+		if err != nil {
+			log.Error("failed to fetch top n analytics: ", err)
+			continue
+		}
+
+		byZoneTag := make(map[string]*zoneRespTopN, len(r.Viewer.Zones))
+		for i := range r.Viewer.Zones {
+			byZoneTag[r.Viewer.Zones[i].ZoneTag] = &r.Viewer.Zones[i]
+		}
+
+		// Zones without traffic are missing in the response, their series are
+		// cleared too so that values falling out of the top N expire
+		for _, zoneID := range zoneIDs {
+			name, account := findZoneAccountName(zones, zoneID)
+			z, exists := byZoneTag[zoneID]
+			if !exists {
+				z = &zoneRespTopN{}
+			}
+			add(z, name, account)
+		}
+	}
+}
+
+func addTopNGroups(z *zoneRespTopN, name string, account string) {
+	// Clear stale series for this zone/account
+	label := prometheus.Labels{"zone": name, "account": account}
+	for _, g := range []*prometheus.GaugeVec{
+		zoneTopPathRequests, zoneTopPathBytes, zoneTopHostRequests, zoneTopHostBytes,
+		zoneTopUserAgentRequests, zoneTopUserAgentBytes, zoneTopASNRequests, zoneTopASNBytes,
+		zoneTopClientIPRequests, zoneTopClientIPBytes, zoneTopDeviceTypeRequests, zoneTopDeviceTypeBytes,
+	} {
+		g.DeletePartialMatch(label)
+	}
+
+	var sampleInterval sampleIntervalAverage
+	for _, groups := range [][]topNGroup{z.TopPaths, z.TopHosts, z.TopUserAgents, z.TopASNs, z.TopClientIPs, z.TopDeviceTypes} {
+		for _, g := range groups {
+			sampleInterval.add(g.Count, g.Avg.SampleInterval)
+		}
+	}
+	sampleInterval.set(name, account, sampledDatasetTopN)
+
+	addTopNDimension(zoneTopPathRequests, zoneTopPathBytes, z.TopPaths, z.TopPathsByBytes, func(g topNGroup) prometheus.Labels {
+		return prometheus.Labels{"zone": name, "account": account, "path": g.Dimensions.ClientRequestPath}
+	})
+	addTopNDimension(zoneTopHostRequests, zoneTopHostBytes, z.TopHosts, z.TopHostsByBytes, func(g topNGroup) prometheus.Labels {
+		return prometheus.Labels{"zone": name, "account": account, "host": g.Dimensions.ClientRequestHTTPHost}
+	})
+	addTopNDimension(zoneTopUserAgentRequests, zoneTopUserAgentBytes, z.TopUserAgents, z.TopUserAgentsByBytes, func(g topNGroup) prometheus.Labels {
+		return prometheus.Labels{"zone": name, "account": account, "user_agent": g.Dimensions.UserAgent}
+	})
+	addTopNDimension(zoneTopASNRequests, zoneTopASNBytes, z.TopASNs, z.TopASNsByBytes, func(g topNGroup) prometheus.Labels {
+		return prometheus.Labels{"zone": name, "account": account, "asn": g.Dimensions.ClientAsn, "asn_description": g.Dimensions.ClientASNDescription}
+	})
+	addTopNDimension(zoneTopClientIPRequests, zoneTopClientIPBytes, z.TopClientIPs, z.TopClientIPsByBytes, func(g topNGroup) prometheus.Labels {
+		return prometheus.Labels{"zone": name, "account": account, "client_ip": g.Dimensions.ClientIP}
+	})
+	addTopNDimension(zoneTopDeviceTypeRequests, zoneTopDeviceTypeBytes, z.TopDeviceTypes, z.TopDeviceTypesByBytes, func(g topNGroup) prometheus.Labels {
+		return prometheus.Labels{"zone": name, "account": account, "device_type": g.Dimensions.ClientDeviceType}
+	})
+}
+
+// addTopNDimension exports the requests of the top N values by requests and
+// the bytes of the top N values by bytes, the two lists can differ.
+func addTopNDimension(requests, bytes *prometheus.GaugeVec, byRequests, byBytes []topNGroup, labels func(topNGroup) prometheus.Labels) {
+	for _, g := range byRequests {
+		requests.With(sampledLabels(labels(g), g.Avg.SampleInterval)).Set(sampled(g.Count, g.Avg.SampleInterval))
+	}
+	for _, g := range byBytes {
+		bytes.With(sampledLabels(labels(g), g.Avg.SampleInterval)).Set(sampled(g.Sum.EdgeResponseBytes, g.Avg.SampleInterval))
+	}
+}
+
+func addTopNThreatGroups(z *zoneRespTopN, name string, account string) {
+	// Clear stale series for this zone/account
+	zoneTopASNThreats.DeletePartialMatch(prometheus.Labels{"zone": name, "account": account})
+
+	var sampleInterval sampleIntervalAverage
+	for _, g := range z.TopASNThreats {
+		sampleInterval.add(g.Count, g.Avg.SampleInterval)
+	}
+	sampleInterval.set(name, account, sampledDatasetTopNThreats)
+
+	for _, g := range z.TopASNThreats {
+		labels := prometheus.Labels{"zone": name, "account": account, "asn": g.Dimensions.ClientAsn, "asn_description": g.Dimensions.ClientASNDescription}
+		zoneTopASNThreats.With(sampledLabels(labels, g.Avg.SampleInterval)).Set(sampled(g.Count, g.Avg.SampleInterval))
+	}
+}
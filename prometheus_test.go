@@ -0,0 +1,163 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetBotScoreBucket(t *testing.T) {
+	tests := []struct {
+		score uint8
+		want  string
+	}{
+		{0, "not_computed"},
+		{1, "automated"},
+		{2, "likely_automated"},
+		{29, "likely_automated"},
+		{30, "likely_human"},
+		{99, "likely_human"},
+	}
+
+	for _, tt := range tests {
+		if got := getBotScoreBucket(tt.score); got != tt.want {
+			t.Errorf("getBotScoreBucket(%d) = %s, want %s", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestGetWAFAttackScoreBucket(t *testing.T) {
+	tests := []struct {
+		score uint8
+		want  string
+	}{
+		{0, "not_scored"},
+		{1, "attack"},
+		{20, "attack"},
+		{21, "likely_attack"},
+		{50, "likely_attack"},
+		{51, "likely_clean"},
+		{80, "likely_clean"},
+		{81, "clean"},
+		{99, "clean"},
+		{100, "not_scored"},
+	}
+
+	for _, tt := range tests {
+		if got := getWAFAttackScoreBucket(tt.score); got != tt.want {
+			t.Errorf("getWAFAttackScoreBucket(%d) = %s, want %s", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestIsPageShieldScoreMalicious(t *testing.T) {
+	tests := []struct {
+		score int64
+		want  bool
+	}{
+		{0, false},
+		{1, true},
+		{49, true},
+		{50, false},
+		{99, false},
+	}
+
+	for _, tt := range tests {
+		if got := isPageShieldScoreMalicious(tt.score); got != tt.want {
+			t.Errorf("isPageShieldScoreMalicious(%d) = %t, want %t", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestNextPageShieldScriptState(t *testing.T) {
+	earlier := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	now := earlier.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		previous pageShieldScriptState
+		seen     bool
+		hash     string
+		want     pageShieldScriptState
+	}{
+		{"first seen", pageShieldScriptState{}, false, "a", pageShieldScriptState{hash: "a"}},
+		{"unchanged", pageShieldScriptState{hash: "a"}, true, "a", pageShieldScriptState{hash: "a"}},
+		{"changed", pageShieldScriptState{hash: "a"}, true, "b", pageShieldScriptState{hash: "b", changedAt: now}},
+		{"unchanged after change", pageShieldScriptState{hash: "b", changedAt: earlier}, true, "b", pageShieldScriptState{hash: "b", changedAt: earlier}},
+		{"hash missing", pageShieldScriptState{hash: "a", changedAt: earlier}, true, "", pageShieldScriptState{hash: "a", changedAt: earlier}},
+	}
+
+	for _, tt := range tests {
+		if got := nextPageShieldScriptState(tt.previous, tt.seen, tt.hash, now); got != tt.want {
+			t.Errorf("%s: nextPageShieldScriptState() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCompareCloudflaredVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2024.2.1", "2024.2.1", 0},
+		{"2024.2.1", "2024.10.0", -1},
+		{"2024.10.0", "2024.2.1", 1},
+		{"2025.1.0", "2024.12.9", 1},
+		{"2024.2", "2024.2.1", -1},
+		{"", "2024.2.1", -1},
+		{"2024.2.1", "", 1},
+		{"", "", 0},
+	}
+
+	for _, tt := range tests {
+		if got := compareCloudflaredVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareCloudflaredVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestGetR2OperationClass(t *testing.T) {
+	tests := []struct {
+		action string
+		want   string
+		read   bool
+	}{
+		{"PutObject", "A", false},
+		{"ListObjects", "A", false},
+		{"CompleteMultipartUpload", "A", false},
+		{"GetObject", "B", true},
+		{"HeadObject", "B", false},
+		{"DeleteObject", "free", false},
+		{"SomeNewAction", "unknown", false},
+	}
+
+	for _, tt := range tests {
+		if got := getR2OperationClass(tt.action); got != tt.want {
+			t.Errorf("getR2OperationClass(%s) = %s, want %s", tt.action, got, tt.want)
+		}
+		if got := isR2ReadOperation(tt.action); got != tt.read {
+			t.Errorf("isR2ReadOperation(%s) = %t, want %t", tt.action, got, tt.read)
+		}
+	}
+}
+
+func TestGetRUMPathClass(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"", "/"},
+		{"/", "/"},
+		{"/about", "/*"},
+		{"/about/", "/about/*"},
+		{"/3f9a1c", "/*"},
+		{"/blog/2024/hello-world", "/blog/*"},
+		{"/blog/", "/blog/*"},
+		{"docs/getting-started", "/docs/*"},
+	}
+
+	for _, tt := range tests {
+		if got := getRUMPathClass(tt.path); got != tt.want {
+			t.Errorf("getRUMPathClass(%q) = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+}
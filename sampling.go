@@ -0,0 +1,101 @@
+package main
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+// Adaptive datasets reported by cloudflare_zone_sample_interval.
+const (
+	sampledDatasetColocation     = "colocation"
+	sampledDatasetFirewallEvents = "firewall_events"
+	sampledDatasetHTTPOrigin     = "http_requests_origin"
+	sampledDatasetHTTPEdge       = "http_requests_edge"
+	sampledDatasetLoadBalancing  = "load_balancing_requests"
+	sampledDatasetBots           = "bot_requests"
+	sampledDatasetArgo           = "argo"
+	sampledDatasetTieredCache    = "tiered_cache"
+	sampledDatasetTopN           = "top_n"
+	sampledDatasetTopNThreats    = "top_n_threats"
+	sampledDatasetSecurityEvents = "security_events"
+	sampledDatasetWAFAttackScore = "waf_attack_score"
+	sampledDatasetAPIShield      = "api_shield_events"
+	sampledDatasetSpectrum       = "spectrum"
+	sampledDatasetL7DDoS         = "l7_ddos"
+	sampledDatasetEmailRouting   = "email_routing"
+
+	// estimatedLabel marks values scaled by sampling_correction
+	estimatedLabel = "estimated"
+)
+
+// sampled scales a count or sum of a sampled group to an estimated total.
+// Without sampling_correction the value is returned as reported.
+func sampled(value uint64, sampleInterval float64) float64 {
+	if !isEstimated(sampleInterval) {
+		return float64(value)
+	}
+	return float64(value) * sampleInterval
+}
+
+// isEstimated reports whether sampled scales a value of a group with the
+// given sample interval.
+func isEstimated(sampleInterval float64) bool {
+	return viper.GetBool("sampling_correction") && sampleInterval > 1
+}
+
+// sampledLabels sets the estimated label of a value of a group with the
+// given sample interval, the value is written with sampled.
+func sampledLabels(labels prometheus.Labels, sampleInterval float64) prometheus.Labels {
+	labels[estimatedLabel] = strconv.FormatBool(isEstimated(sampleInterval))
+	return labels
+}
+
+// exportedLabels returns the labels a metric is exported with. The estimated
+// label is only exported with sampling_correction, without it every value is
+// reported as sampled and the label would always be false.
+func exportedLabels(labels []string) []string {
+	if viper.GetBool("sampling_correction") || !slices.Contains(labels, estimatedLabel) {
+		return labels
+	}
+	return slices.DeleteFunc(slices.Clone(labels), func(label string) bool {
+		return label == estimatedLabel
+	})
+}
+
+// estimatedMetrics returns the metrics with an estimated label.
+func estimatedMetrics() []MetricName {
+	var names []MetricName
+	for name, collector := range metricCollectors() {
+		if slices.Contains(metricDefinitions[collector].labels, estimatedLabel) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// sampleIntervalAverage averages the sample interval of the groups of a
+// dataset, weighted by their count.
+type sampleIntervalAverage struct {
+	count    float64
+	weighted float64
+}
+
+func (a *sampleIntervalAverage) add(count uint64, sampleInterval float64) {
+	if sampleInterval < 1 {
+		sampleInterval = 1
+	}
+	a.count += float64(count)
+	a.weighted += float64(count) * sampleInterval
+}
+
+func (a *sampleIntervalAverage) set(name, account, dataset string) {
+	labels := prometheus.Labels{"zone": name, "account": account, "dataset": dataset}
+	if a.count == 0 {
+		zoneSampleInterval.Delete(labels)
+		return
+	}
+	zoneSampleInterval.With(labels).Set(a.weighted / a.count)
+}